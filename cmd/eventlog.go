@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// maxLogEntries is how many entries the session log keeps before forgetting the oldest
+const maxLogEntries = 500
+
+// logEntry is a single change observed while watching
+type logEntry struct {
+	Time    time.Time
+	Message string
+}
+
+// eventLog lists every change observed since watching started, oldest first
+type eventLog []logEntry
+
+// add appends an entry, forgetting the oldest beyond maxLogEntries
+func (l *eventLog) add(t time.Time, message string) {
+	*l = append(*l, logEntry{Time: t, Message: message})
+	if len(*l) > maxLogEntries {
+		*l = (*l)[len(*l)-maxLogEntries:]
+	}
+}
+
+// record logs the outcome of a poll: the first summary, what changed since the previous one,
+// and when polls start and stop failing
+func (l *eventLog) record(event status.Event, previous *status.SystemStatus, previousErr error) {
+	switch {
+	case event.Err != nil:
+		if previousErr == nil {
+			l.add(event.Time, "Unable to retrieve the status: "+event.Err.Error())
+		}
+		return
+	case previousErr != nil:
+		l.add(event.Time, "Retrieved the status again")
+	}
+	if event.Status == nil {
+		return
+	}
+	if previous == nil {
+		l.add(event.Time, "Started watching: "+event.Status.Overall().Description)
+		return
+	}
+	for _, change := range status.Diff(previous, event.Status) {
+		l.add(event.Time, changeMessage(change))
+	}
+}
+
+// changeMessage describes a change for the session log
+func changeMessage(change status.Change) string {
+	name := change.Incident.Name
+	if name == "" {
+		name = "incident " + change.Incident.ID
+	}
+	switch change.Kind {
+	case status.ComponentChanged:
+		return fmt.Sprintf("%s %s → %s", change.Component.Component, statusLabel(change.PreviousStatus), statusLabel(change.Component.Status))
+	case status.IncidentCreated:
+		return "New incident: " + name
+	case status.IncidentUpdated:
+		return fmt.Sprintf("New incident update: %s (%s)", name, incidentStatusLabel(change.Incident.Status))
+	case status.IncidentResolved:
+		return "Resolved: " + name
+	default:
+		return "Unknown change"
+	}
+}
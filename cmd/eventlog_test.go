@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+func TestEventLog_Record(t *testing.T) {
+	start := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	first := &status.SystemStatus{
+		Components: []status.Components{{ID: "actions", Component: "Actions", Status: status.COMPONENT_OPERATIONAL}},
+	}
+	second := &status.SystemStatus{
+		Components: []status.Components{{ID: "actions", Component: "Actions", Status: status.COMPONENT_DEGREDADED_PERFORMANCE}},
+		Incidents:  []status.Incidents{{ID: "incident123", Name: "Disruption with some GitHub services", Status: status.INCIDENT_INVESTIGATING}},
+	}
+	pollErr := errors.New("connection refused")
+
+	var log eventLog
+	log.record(status.Event{Time: start, Status: first}, nil, nil)
+	log.record(status.Event{Time: start.Add(time.Minute), Status: first, NotModified: true}, first, nil)
+	log.record(status.Event{Time: start.Add(2 * time.Minute), Err: pollErr}, first, nil)
+	log.record(status.Event{Time: start.Add(3 * time.Minute), Err: pollErr}, first, pollErr)
+	log.record(status.Event{Time: start.Add(4 * time.Minute), Status: second}, first, pollErr)
+
+	expected := eventLog{
+		{Time: start, Message: "Started watching: All Systems Operational"},
+		{Time: start.Add(2 * time.Minute), Message: "Unable to retrieve the status: connection refused"},
+		{Time: start.Add(4 * time.Minute), Message: "Retrieved the status again"},
+		{Time: start.Add(4 * time.Minute), Message: "Actions Operational → Degraded Performance"},
+		{Time: start.Add(4 * time.Minute), Message: "New incident: Disruption with some GitHub services"},
+	}
+	if len(log) != len(expected) {
+		t.Fatalf("expected %d entries, got %d: %v", len(expected), len(log), log)
+	}
+	for i := range expected {
+		if log[i] != expected[i] {
+			t.Errorf("entry %d = %v, expected %v", i, log[i], expected[i])
+		}
+	}
+}
+
+func TestEventLog_ForgetsOldestEntries(t *testing.T) {
+	var log eventLog
+	for i := 0; i < maxLogEntries+10; i++ {
+		log.add(time.Time{}, "entry")
+	}
+	if len(log) != maxLogEntries {
+		t.Errorf("expected the log to be limited to %d entries, got %d", maxLogEntries, len(log))
+	}
+}
+
+func TestChangeMessage(t *testing.T) {
+	incident := status.Incidents{ID: "incident123", Name: "Incident with Actions", Status: status.INCIDENT_IDENTIFIED}
+	tests := []struct {
+		change   status.Change
+		expected string
+	}{
+		{status.Change{Kind: status.IncidentUpdated, Incident: incident}, "New incident update: Incident with Actions (Identified)"},
+		{status.Change{Kind: status.IncidentResolved, Incident: incident}, "Resolved: Incident with Actions"},
+		{status.Change{Kind: status.IncidentCreated, Incident: status.Incidents{ID: "abc"}}, "New incident: incident abc"},
+	}
+	for _, tt := range tests {
+		if result := changeMessage(tt.change); result != tt.expected {
+			t.Errorf("changeMessage() = %q, expected %q", result, tt.expected)
+		}
+	}
+}
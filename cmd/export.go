@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the current status to a file",
+	Long: `Write the current status of github.com to a file that can be shared or published,
+for example from a cron job.
+
+The HTML export is a self-contained page with the status of every component, the timeline
+of each active incident and a chart of the incidents over the last 90 days.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		htmlPath, err := cmd.Flags().GetString("html")
+		if err != nil {
+			log.Fatal(err)
+		}
+		client, err := clientFromFlags(cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+		timeZone, err := cmd.Flags().GetString("tz")
+		if err != nil {
+			log.Fatal(err)
+		}
+		timeFormat, err := cmd.Flags().GetString("time-format")
+		if err != nil {
+			log.Fatal(err)
+		}
+		times, err := newTimeFormatter(timeZone, timeFormat)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		summary, _, err := client.PollContext(cmd.Context())
+		if err != nil {
+			log.Fatalf("unable to retrieve the current status: %s", err)
+		}
+		// The history only adds the timeline chart, so the page is still worth writing without it
+		history, err := client.IncidentHistory(cmd.Context())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to retrieve the incident history, it will be left out: %s\n", err)
+		}
+
+		snap := Snapshot{
+			Summary:    summary,
+			LastUpdate: time.Now(),
+		}
+		output := htmlRenderer{options: renderOptions{times: times}, history: history}.Render(snap, 0, 0)
+		if err := os.WriteFile(htmlPath, []byte(output), 0o644); err != nil {
+			log.Fatalf("unable to write %s: %s", htmlPath, err)
+		}
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("html", "", "Path of the HTML file to write")
+	_ = exportCmd.MarkFlagRequired("html")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// highlightPolls is for how many polls a change stays highlighted
+const highlightPolls = 3
+
+// highlights tracks the components and incident updates that changed recently, along with how
+// many more polls each stays highlighted for
+type highlights struct {
+	components map[string]int // by component ID
+	updates    map[string]int // by updateKey
+}
+
+func newHighlights() highlights {
+	return highlights{
+		components: map[string]int{},
+		updates:    map[string]int{},
+	}
+}
+
+// record fades the existing highlights by a poll and highlights what changed from previous to
+// current. Nothing is highlighted on the first poll, when previous is nil.
+func (h highlights) record(previous, current *status.SystemStatus) {
+	fade(h.components)
+	fade(h.updates)
+	for _, change := range status.Diff(previous, current) {
+		switch change.Kind {
+		case status.ComponentChanged:
+			h.components[change.Component.ID] = highlightPolls
+		case status.IncidentCreated, status.IncidentUpdated:
+			seen := map[string]bool{}
+			for _, incident := range previous.Incidents {
+				if incident.ID == change.Incident.ID {
+					for _, update := range incident.IncidentUpdates {
+						seen[updateKey(incident, update)] = true
+					}
+				}
+			}
+			for _, update := range change.Incident.IncidentUpdates {
+				if key := updateKey(change.Incident, update); !seen[key] {
+					h.updates[key] = highlightPolls
+				}
+			}
+		}
+	}
+}
+
+// component reports whether a component's status changed recently
+func (h highlights) component(id string) bool {
+	return h.components[id] > 0
+}
+
+// update reports whether an incident update was posted recently
+func (h highlights) update(incident status.Incidents, update status.IncidentUpdate) bool {
+	return h.updates[updateKey(incident, update)] > 0
+}
+
+// fade takes a poll off every highlight, forgetting those that have run out
+func fade(polls map[string]int) {
+	for key := range polls {
+		polls[key]--
+		if polls[key] <= 0 {
+			delete(polls, key)
+		}
+	}
+}
+
+// updateKey identifies an incident update, falling back to when it was posted for updates
+// without an ID
+func updateKey(incident status.Incidents, update status.IncidentUpdate) string {
+	if update.ID != "" {
+		return update.ID
+	}
+	var posted string
+	if update.Timestamp != nil && update.Timestamp.Time != nil {
+		posted = update.Timestamp.Format(time.RFC3339Nano)
+	}
+	return incident.ID + "/" + posted + "/" + string(update.Status)
+}
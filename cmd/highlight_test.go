@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+func TestHighlights_Record(t *testing.T) {
+	posted := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	first := &status.SystemStatus{
+		Components: []status.Components{
+			{ID: "actions", Status: status.COMPONENT_OPERATIONAL},
+			{ID: "pages", Status: status.COMPONENT_OPERATIONAL},
+		},
+		Incidents: []status.Incidents{
+			{ID: "incident123", Status: status.INCIDENT_INVESTIGATING, IncidentUpdates: []status.IncidentUpdate{
+				{Status: status.INCIDENT_INVESTIGATING, Timestamp: &status.Time{Time: &posted}},
+			}},
+		},
+	}
+	second := &status.SystemStatus{
+		Components: []status.Components{
+			{ID: "actions", Status: status.COMPONENT_PARTIAL_OUTAGE},
+			{ID: "pages", Status: status.COMPONENT_OPERATIONAL},
+		},
+		Incidents: []status.Incidents{
+			{ID: "incident123", Status: status.INCIDENT_IDENTIFIED, IncidentUpdates: []status.IncidentUpdate{
+				{ID: "update2", Status: status.INCIDENT_IDENTIFIED},
+				{Status: status.INCIDENT_INVESTIGATING, Timestamp: &status.Time{Time: &posted}},
+			}},
+		},
+	}
+	incident := second.Incidents[0]
+
+	changes := newHighlights()
+	changes.record(nil, first)
+	if changes.component("actions") || changes.update(incident, incident.IncidentUpdates[1]) {
+		t.Error("expected nothing to be highlighted after the first poll")
+	}
+
+	changes.record(first, second)
+	if !changes.component("actions") {
+		t.Error("expected the component whose status changed to be highlighted")
+	}
+	if changes.component("pages") {
+		t.Error("expected the component whose status didn't change not to be highlighted")
+	}
+	if !changes.update(incident, incident.IncidentUpdates[0]) {
+		t.Error("expected the new incident update to be highlighted")
+	}
+	if changes.update(incident, incident.IncidentUpdates[1]) {
+		t.Error("expected the incident update seen before not to be highlighted")
+	}
+
+	// Highlights fade once the same summary has been seen for highlightPolls more polls
+	for i := 1; i < highlightPolls; i++ {
+		changes.record(second, second)
+	}
+	if !changes.component("actions") || !changes.update(incident, incident.IncidentUpdates[0]) {
+		t.Errorf("expected changes to stay highlighted for %d polls", highlightPolls)
+	}
+	changes.record(second, second)
+	if changes.component("actions") || changes.update(incident, incident.IncidentUpdates[0]) {
+		t.Errorf("expected changes to fade after %d polls", highlightPolls)
+	}
+}
+
+func TestUpdateKey_WithoutTimestamp(t *testing.T) {
+	incident := status.Incidents{ID: "incident123"}
+	key := updateKey(incident, status.IncidentUpdate{Status: status.INCIDENT_INVESTIGATING})
+	if key != "incident123//investigating" {
+		t.Errorf("unexpected key for an update without an ID or timestamp: %q", key)
+	}
+}
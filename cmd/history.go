@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// historyLength is how many polls the history strips cover, an hour when polling every minute
+const historyLength = 60
+
+// minStripWidth is the shortest a history strip is shortened to before it is left out
+const minStripWidth = 5
+
+// sparks draws each component status as a bar, taller bars are more severe so the strip can be
+// read without colors
+var sparks = map[status.ComponentStatus]string{
+	status.COMPONENT_OPERATIONAL:            "▁",
+	status.COMPONENT_UNDER_MAINTENANCE:      "▂",
+	status.COMPONENT_DEGREDADED_PERFORMANCE: "▄",
+	status.COMPONENT_PARTIAL_OUTAGE:         "▆",
+	status.COMPONENT_MAJOR_OUTAGE:           "█",
+}
+
+// unknownSpark marks a poll where a component's status wasn't known to this extension
+const unknownSpark = "·"
+
+// componentHistory is the status of each component, by ID, at every poll this session, oldest first
+type componentHistory map[string][]status.ComponentStatus
+
+// record adds the status of every component in summary, forgetting polls beyond historyLength
+func (h componentHistory) record(summary *status.SystemStatus) {
+	for _, component := range summary.Components {
+		samples := append(h[component.ID], component.Status)
+		if len(samples) > historyLength {
+			samples = samples[len(samples)-historyLength:]
+		}
+		h[component.ID] = samples
+	}
+}
+
+// length is the number of polls recorded for the component seen most often
+func (h componentHistory) length() int {
+	length := 0
+	for _, samples := range h {
+		length = max(length, len(samples))
+	}
+	return length
+}
+
+// sparkline draws the most recent width polls of a component, newest on the right. Components
+// seen in fewer polls are padded on the left so strips line up.
+func (h componentHistory) sparkline(id string, width int, theme statusTheme) string {
+	samples := h[id]
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+	var strip strings.Builder
+	strip.WriteString(strings.Repeat(" ", width-len(samples)))
+	for _, sample := range samples {
+		spark, ok := sparks[sample]
+		if !ok {
+			spark = unknownSpark
+		}
+		strip.WriteString(theme.style(sample, spark))
+	}
+	return strip.String()
+}
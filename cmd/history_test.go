@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+func TestComponentHistory_Record(t *testing.T) {
+	history := componentHistory{}
+	summary := &status.SystemStatus{Components: []status.Components{{ID: "actions", Status: status.COMPONENT_OPERATIONAL}}}
+	for i := 0; i < historyLength+5; i++ {
+		history.record(summary)
+	}
+	summary.Components[0].Status = status.COMPONENT_MAJOR_OUTAGE
+	history.record(summary)
+
+	samples := history["actions"]
+	if len(samples) != historyLength {
+		t.Fatalf("expected history to be limited to %d polls, got %d", historyLength, len(samples))
+	}
+	if samples[len(samples)-1] != status.COMPONENT_MAJOR_OUTAGE {
+		t.Errorf("expected the newest poll last, got %q", samples[len(samples)-1])
+	}
+	if history.length() != historyLength {
+		t.Errorf("expected length %d, got %d", historyLength, history.length())
+	}
+}
+
+func TestComponentHistory_Sparkline(t *testing.T) {
+	history := componentHistory{
+		"actions": {
+			status.COMPONENT_OPERATIONAL,
+			status.COMPONENT_DEGREDADED_PERFORMANCE,
+			status.COMPONENT_PARTIAL_OUTAGE,
+			status.COMPONENT_MAJOR_OUTAGE,
+			"unknown",
+		},
+	}
+	theme, err := newStatusTheme("monochrome", "none")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		width    int
+		expected string
+	}{
+		{name: "whole history", id: "actions", width: 5, expected: "▁▄▆█·"},
+		{name: "most recent polls", id: "actions", width: 3, expected: "▆█·"},
+		{name: "padded on the left", id: "actions", width: 7, expected: "  ▁▄▆█·"},
+		{name: "component never seen", id: "pages", width: 3, expected: "   "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := stripAnsiCodes(history.sparkline(tt.id, tt.width, theme))
+			if result != tt.expected {
+				t.Errorf("sparkline(%q, %d) = %q, expected %q", tt.id, tt.width, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+)
+
+// pollIntervals are the intervals '+' and '-' step through in watch mode, shortest first
+var pollIntervals = []time.Duration{
+	15 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	30 * time.Minute,
+}
+
+// nextInterval steps from current to the next longer interval when step is positive, or the
+// next shorter one when it is negative, staying within pollIntervals
+func nextInterval(current time.Duration, step int) time.Duration {
+	if step > 0 {
+		for _, interval := range pollIntervals {
+			if interval > current {
+				return interval
+			}
+		}
+		return pollIntervals[len(pollIntervals)-1]
+	}
+	for i := len(pollIntervals) - 1; i >= 0; i-- {
+		if pollIntervals[i] < current {
+			return pollIntervals[i]
+		}
+	}
+	return pollIntervals[0]
+}
+
+// intervalKeyStep is the step through pollIntervals for a key pressed in watch mode: '+' polls
+// more often by moving to a shorter interval and '-' polls less often by moving to a longer one
+func intervalKeyStep(key byte) int {
+	if key == '+' || key == '=' {
+		return -1
+	}
+	return 1
+}
+
+// shortDuration formats d without trailing zero units, such as "1m" rather than "1m0s"
+func shortDuration(d time.Duration) string {
+	text := d.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
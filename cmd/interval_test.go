@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextInterval(t *testing.T) {
+	tests := []struct {
+		current  time.Duration
+		step     int
+		expected time.Duration
+	}{
+		{time.Minute, 1, 2 * time.Minute},
+		{time.Minute, -1, 30 * time.Second},
+		{15 * time.Second, -1, 15 * time.Second},
+		{30 * time.Minute, 1, 30 * time.Minute},
+		{45 * time.Second, 1, time.Minute},
+		{45 * time.Second, -1, 30 * time.Second},
+		{time.Hour, -1, 30 * time.Minute},
+		{time.Second, 1, 15 * time.Second},
+	}
+	for _, tt := range tests {
+		if result := nextInterval(tt.current, tt.step); result != tt.expected {
+			t.Errorf("nextInterval(%s, %d) = %s, expected %s", tt.current, tt.step, result, tt.expected)
+		}
+	}
+}
+
+func TestIntervalKeys(t *testing.T) {
+	tests := map[byte]time.Duration{
+		'+': 30 * time.Second,
+		'=': 30 * time.Second,
+		'-': 2 * time.Minute,
+		'_': 2 * time.Minute,
+	}
+	for key, expected := range tests {
+		if result := nextInterval(time.Minute, intervalKeyStep(key)); result != expected {
+			t.Errorf("pressing %q from 1m polls every %s, expected %s", key, result, expected)
+		}
+	}
+}
+
+func TestShortDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		15 * time.Second:            "15s",
+		time.Minute:                 "1m",
+		90 * time.Second:            "1m30s",
+		time.Hour:                   "1h",
+		time.Hour + 30*time.Minute:  "1h30m",
+		2*time.Hour + 5*time.Second: "2h0m5s",
+	}
+	for d, expected := range tests {
+		if result := shortDuration(d); result != expected {
+			t.Errorf("shortDuration(%s) = %q, expected %q", d, result, expected)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pterm/pterm"
+)
+
+const (
+	// sideBySideWidth is the terminal width from which the status and incident boxes are
+	// placed next to each other instead of stacked
+	sideBySideWidth = 120
+	// columnGap is the space between columns of components and between boxes side by side
+	columnGap = 2
+	// boxFrameWidth and boxFrameHeight are the space a box's borders and padding take up
+	boxFrameWidth  = 4
+	boxFrameHeight = 2
+	// screenMargin keeps the boxes clear of the last column, which some terminals wrap at
+	screenMargin = 2
+	// minContentWidth is the narrowest a box gets, however narrow the terminal is
+	minContentWidth = 20
+	// minIncidentLines is how many lines of incident updates are kept when there are more
+	// components than fit on screen
+	minIncidentLines = 3
+	// maxStaleErrorLines is how many lines the error gets below the stale data warning
+	maxStaleErrorLines = 2
+)
+
+// box draws a titled box around lines
+func box(title string, lines []string) string {
+	content := make([]string, len(lines))
+	for i, line := range lines {
+		// pterm only ignores escape sequences when measuring lines that contain a full reset,
+		// which lines styled by incident markup don't
+		if strings.Contains(line, "\x1b") && !strings.Contains(line, "\x1b[0m") {
+			line += "\x1b[0m"
+		}
+		content[i] = line
+	}
+	return pterm.DefaultBox.WithTitle(title).WithTitleTopCenter().Sprint(strings.Join(content, "\n"))
+}
+
+// flowColumns arranges items, which are at most itemWidth cells wide, into as many columns as
+// fit in width. Items run down each column before moving on to the next one, and the columns
+// are balanced so the last one isn't left nearly empty.
+func flowColumns(items []string, itemWidth, width int) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	columns := (width + columnGap) / (itemWidth + columnGap)
+	columns = max(1, min(columns, len(items)))
+	rows := (len(items) + columns - 1) / columns
+	columns = (len(items) + rows - 1) / rows
+
+	lines := make([]string, rows)
+	for row := range lines {
+		var line strings.Builder
+		for column := 0; column < columns; column++ {
+			i := column*rows + row
+			if i >= len(items) {
+				break
+			}
+			if column > 0 {
+				line.WriteString(strings.Repeat(" ", columnGap))
+			}
+			line.WriteString(padLineToWidth(items[i], itemWidth))
+		}
+		lines[row] = line.String()
+	}
+	return lines
+}
+
+// joinColumns places two blocks of text next to each other, separated by gap spaces
+func joinColumns(left, right string, gap int) string {
+	leftLines := strings.Split(left, "\n")
+	rightLines := strings.Split(right, "\n")
+	leftWidth := 0
+	for _, line := range leftLines {
+		leftWidth = max(leftWidth, displayWidth(line))
+	}
+
+	lines := make([]string, max(len(leftLines), len(rightLines)))
+	for i := range lines {
+		var l, r string
+		if i < len(leftLines) {
+			l = leftLines[i]
+		}
+		if i < len(rightLines) {
+			r = rightLines[i]
+		}
+		lines[i] = padLineToWidth(l, leftWidth) + strings.Repeat(" ", gap) + r
+	}
+	return strings.Join(lines, "\n")
+}
+
+// padLines adds empty lines to the end of lines until there are at least n of them
+func padLines(lines []string, n int) []string {
+	for len(lines) < n {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// truncateLines limits lines to at most n, replacing the ones that don't fit with a note
+// saying how many were left out
+func truncateLines(lines []string, n int) []string {
+	n = max(n, 1)
+	if len(lines) <= n {
+		return lines
+	}
+	hidden := len(lines) - n + 1
+	return append(lines[:n-1:n-1], pterm.Gray(fmt.Sprintf("… %d more lines", hidden)))
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFlowColumns(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []string
+		itemWidth int
+		width     int
+		expected  []string
+	}{
+		{
+			name:      "no items",
+			items:     nil,
+			itemWidth: 0,
+			width:     20,
+			expected:  nil,
+		},
+		{
+			name:      "single column when narrow",
+			items:     []string{"a", "b", "c"},
+			itemWidth: 1,
+			width:     3,
+			expected:  []string{"a", "b", "c"},
+		},
+		{
+			name:      "items run down each column",
+			items:     []string{"a", "b", "c", "d"},
+			itemWidth: 1,
+			width:     4,
+			expected:  []string{"a  c", "b  d"},
+		},
+		{
+			name:      "columns are balanced",
+			items:     []string{"a", "b", "c", "d", "e"},
+			itemWidth: 1,
+			width:     10,
+			expected:  []string{"a  c  e", "b  d"},
+		},
+		{
+			name:      "items are padded to the widest",
+			items:     []string{"ab", "c"},
+			itemWidth: 2,
+			width:     6,
+			expected:  []string{"ab  c "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := flowColumns(tt.items, tt.itemWidth, tt.width)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("flowColumns(%q, %d, %d) = %q, expected %q", tt.items, tt.itemWidth, tt.width, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJoinColumns(t *testing.T) {
+	result := joinColumns("ab\nc", "1\n2\n3", 1)
+	expected := "ab 1\nc  2\n   3"
+	if result != expected {
+		t.Errorf("joinColumns() = %q, expected %q", result, expected)
+	}
+}
+
+func TestTruncateLines(t *testing.T) {
+	lines := []string{"a", "b", "c", "d"}
+	if result := truncateLines(lines, 4); !reflect.DeepEqual(result, lines) {
+		t.Errorf("expected lines that fit to be unchanged, got %q", result)
+	}
+	result := truncateLines(lines, 3)
+	if len(result) != 3 || result[0] != "a" || result[1] != "b" || stripAnsiCodes(result[2]) != "… 2 more lines" {
+		t.Errorf("expected the last lines to be replaced by a note, got %q", result)
+	}
+	if lines[2] != "c" {
+		t.Error("expected truncateLines not to modify its argument")
+	}
+}
+
+func TestBox_AlignsStyledLines(t *testing.T) {
+	lines := []string{"\x1b[1mbold\x1b[22m text", "plain text"}
+	result := strings.Split(box("Title", lines), "\n")
+	width := displayWidth(result[0])
+	for _, line := range result {
+		if displayWidth(line) != width {
+			t.Errorf("expected every line of the box to be %d cells wide, got %d: %q", width, displayWidth(line), stripAnsiCodes(line))
+		}
+	}
+}
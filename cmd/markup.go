@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// markupStyle decides how the formatting found in an incident body is written out
+type markupStyle struct {
+	text    func(string) string // escapes plain text, if needed
+	bold    func(string) string // emphasizes text from <strong>, <b> or **bold**
+	italic  func(string) string // emphasizes text from <em> or <i>
+	link    func(text, url string) string
+	newline string // starts a new line
+	bullet  string // marks an item of an unordered list
+}
+
+func identity(s string) string {
+	return s
+}
+
+// terminalMarkup styles text with ANSI escape codes and turns links into OSC 8 hyperlinks
+var terminalMarkup = markupStyle{
+	text:   identity,
+	bold:   func(s string) string { return "\x1b[1m" + s + "\x1b[22m" },
+	italic: func(s string) string { return "\x1b[3m" + s + "\x1b[23m" },
+	link: func(text, url string) string {
+		return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+	},
+	newline: "\n",
+	bullet:  "• ",
+}
+
+// plainMarkup drops all styling and writes links out in full so they survive copy and paste
+var plainMarkup = markupStyle{
+	text:   identity,
+	bold:   identity,
+	italic: identity,
+	link: func(text, url string) string {
+		if text == url || text == "" {
+			return url
+		}
+		return fmt.Sprintf("%s (%s)", text, url)
+	},
+	newline: "\n",
+	bullet:  "- ",
+}
+
+// htmlMarkup escapes text and writes the formatting back out as the few tags it allows, so the
+// result is safe to place in a page as template.HTML
+var htmlMarkup = markupStyle{
+	text:   template.HTMLEscapeString,
+	bold:   func(s string) string { return "<strong>" + s + "</strong>" },
+	italic: func(s string) string { return "<em>" + s + "</em>" },
+	link: func(text, url string) string {
+		return `<a href="` + template.HTMLEscapeString(url) + `">` + text + "</a>"
+	},
+	newline: "<br>",
+	bullet:  "• ",
+}
+
+// markdownMarkup writes GitHub flavored Markdown that stays on a single line, so it can be
+// used inside a list item or a table cell
+var markdownMarkup = markupStyle{
+	text:   markdownEscaper.Replace,
+	bold:   func(s string) string { return "**" + s + "**" },
+	italic: func(s string) string { return "_" + s + "_" },
+	link: func(text, url string) string {
+		return "[" + text + "](" + markdownURLEscaper.Replace(url) + ")"
+	},
+	newline: "<br>",
+	bullet:  "• ",
+}
+
+// markdownURLEscaper escapes the characters that would end a Markdown link's destination early
+var markdownURLEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29")
+
+var (
+	htmlTagRegex        = regexp.MustCompile(`<\s*(/?)\s*([a-zA-Z][a-zA-Z0-9]*)([^>]*)>`)
+	hrefRegex           = regexp.MustCompile(`(?i)href\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	markdownInlineRegex = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__|\[([^\]]+)\]\((https?://[^)\s]+)\)`)
+	markdownBulletRegex = regexp.MustCompile(`^\s*[-*]\s+`)
+)
+
+// renderMarkup converts the HTML and Markdown Statuspage allows in incident bodies into
+// text formatted with style. Unknown tags are dropped, HTML entities are decoded and control
+// characters are removed, so a body can't write its own escape sequences to the terminal.
+func renderMarkup(body string, style markupStyle) string {
+	m := &markupRenderer{style: style}
+	last := 0
+	for _, loc := range htmlTagRegex.FindAllStringSubmatchIndex(body, -1) {
+		m.writeText(body[last:loc[0]])
+		closing := loc[3] > loc[2]
+		name := strings.ToLower(body[loc[4]:loc[5]])
+		m.writeTag(name, closing, body[loc[6]:loc[7]])
+		last = loc[1]
+	}
+	m.writeText(body[last:])
+	return strings.TrimSpace(strings.TrimSuffix(m.out.String(), style.newline))
+}
+
+// markupRenderer holds the state of the formatting while an incident body is converted
+type markupRenderer struct {
+	style     markupStyle
+	out       strings.Builder
+	bold      int
+	italic    int
+	links     []openLink
+	lists     []*openList
+	atNewline bool // the output ends with a newline
+	trimSpace bool // leading whitespace should be dropped since a line or list item just started
+}
+
+// openLink is an <a> tag whose text is still being written
+type openLink struct {
+	url   string
+	start int
+}
+
+// openList is a <ul> or <ol> tag whose items are still being written
+type openList struct {
+	ordered bool
+	items   int
+}
+
+func (m *markupRenderer) newline() {
+	if m.out.Len() == 0 || m.atNewline {
+		return
+	}
+	m.out.WriteString(m.style.newline)
+	m.atNewline = true
+	m.trimSpace = true
+}
+
+// bullet starts a list item, indenting nested lists
+func (m *markupRenderer) bullet() {
+	m.newline()
+	depth := len(m.lists)
+	marker := m.style.bullet
+	if depth > 0 {
+		list := m.lists[depth-1]
+		list.items++
+		if list.ordered {
+			marker = fmt.Sprintf("%d. ", list.items)
+		}
+		m.out.WriteString(strings.Repeat("  ", depth-1))
+	}
+	m.out.WriteString(marker)
+	m.atNewline = false
+	m.trimSpace = true
+}
+
+// write adds text with the formatting that currently applies
+func (m *markupRenderer) write(text string, bold bool) {
+	if text == "" {
+		return
+	}
+	if m.trimSpace || m.out.Len() == 0 {
+		text = strings.TrimLeft(text, " \t")
+		if text == "" {
+			return
+		}
+	}
+	styled := m.style.text(text)
+	if m.italic > 0 {
+		styled = m.style.italic(styled)
+	}
+	if bold || m.bold > 0 {
+		styled = m.style.bold(styled)
+	}
+	m.out.WriteString(styled)
+	m.atNewline = false
+	m.trimSpace = false
+}
+
+// writeText adds the text between two tags, applying any Markdown formatting it contains
+func (m *markupRenderer) writeText(text string) {
+	text = stripControl(html.UnescapeString(text))
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			m.newline()
+		}
+		if m.atNewline || m.out.Len() == 0 {
+			if loc := markdownBulletRegex.FindStringIndex(line); loc != nil {
+				m.bullet()
+				line = line[loc[1]:]
+			}
+		}
+		last := 0
+		for _, match := range markdownInlineRegex.FindAllStringSubmatchIndex(line, -1) {
+			m.write(line[last:match[0]], false)
+			switch {
+			case match[2] >= 0:
+				m.write(line[match[2]:match[3]], true)
+			case match[4] >= 0:
+				m.write(line[match[4]:match[5]], true)
+			default:
+				start := m.out.Len()
+				m.write(line[match[6]:match[7]], false)
+				m.wrapLink(start, line[match[8]:match[9]])
+			}
+			last = match[1]
+		}
+		m.write(line[last:], false)
+	}
+}
+
+// wrapLink turns everything written since start into a link to url, leaving the text as it is
+// if url isn't a safe http(s) URL
+func (m *markupRenderer) wrapLink(start int, url string) {
+	if !safeURL(url) {
+		return
+	}
+	written := m.out.String()
+	m.out.Reset()
+	m.out.WriteString(written[:start])
+	m.out.WriteString(m.style.link(written[start:], url))
+	m.atNewline = false
+}
+
+// writeTag applies the formatting of an HTML tag
+func (m *markupRenderer) writeTag(name string, closing bool, attributes string) {
+	switch name {
+	case "strong", "b":
+		m.bold += depthChange(closing, m.bold)
+	case "em", "i":
+		m.italic += depthChange(closing, m.italic)
+	case "a":
+		if !closing {
+			url := ""
+			if match := hrefRegex.FindStringSubmatch(attributes); match != nil {
+				url = html.UnescapeString(match[1] + match[2] + match[3])
+			}
+			m.links = append(m.links, openLink{url: url, start: m.out.Len()})
+		} else if len(m.links) > 0 {
+			link := m.links[len(m.links)-1]
+			m.links = m.links[:len(m.links)-1]
+			if link.url != "" {
+				m.wrapLink(link.start, link.url)
+			}
+		}
+	case "ul", "ol":
+		if !closing {
+			m.lists = append(m.lists, &openList{ordered: name == "ol"})
+		} else if len(m.lists) > 0 {
+			m.lists = m.lists[:len(m.lists)-1]
+		}
+		m.newline()
+	case "li":
+		if !closing {
+			m.bullet()
+		}
+	case "br", "p", "div", "h1", "h2", "h3", "h4", "h5", "h6":
+		m.newline()
+	}
+}
+
+// stripControl removes C0 and C1 control characters other than newlines and tabs, which
+// could otherwise start escape sequences or ring the bell
+func stripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// safeURL reports whether a link can be written out: an absolute http(s) URL without the
+// control characters or whitespace that would break out of a hyperlink
+func safeURL(link string) bool {
+	if strings.IndexFunc(link, func(r rune) bool {
+		return r <= 0x20 || (r >= 0x7f && r <= 0x9f)
+	}) >= 0 {
+		return false
+	}
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	return parsed.Scheme == "http" || parsed.Scheme == "https"
+}
+
+// depthChange is how much a nesting depth changes for an opening or closing tag, never
+// letting a stray closing tag take the depth below zero
+func depthChange(closing bool, depth int) int {
+	if !closing {
+		return 1
+	}
+	if depth > 0 {
+		return -1
+	}
+	return 0
+}
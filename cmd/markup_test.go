@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+func TestRenderMarkup_Plain(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "plain text",
+			body:     "We are investigating reports of degraded performance.",
+			expected: "We are investigating reports of degraded performance.",
+		},
+		{
+			name:     "html entities",
+			body:     "Pull requests &amp; issues aren&#39;t loading &gt; 5% of the time",
+			expected: "Pull requests & issues aren't loading > 5% of the time",
+		},
+		{
+			name:     "strong and em tags",
+			body:     "<strong>Update:</strong> the fix is <em>rolling out</em>",
+			expected: "Update: the fix is rolling out",
+		},
+		{
+			name:     "html link",
+			body:     `See <a href="https://github.blog/post">the blog</a> for details`,
+			expected: "See the blog (https://github.blog/post) for details",
+		},
+		{
+			name:     "html link to itself",
+			body:     `See <a href='https://github.blog'>https://github.blog</a>`,
+			expected: "See https://github.blog",
+		},
+		{
+			name:     "unordered list",
+			body:     "Affected services:<ul><li>Actions</li><li> Pages</li></ul>",
+			expected: "Affected services:\n- Actions\n- Pages",
+		},
+		{
+			name:     "ordered list",
+			body:     "Steps:<ol><li>Retry</li><li>Wait</li></ol>Thanks",
+			expected: "Steps:\n1. Retry\n2. Wait\nThanks",
+		},
+		{
+			name:     "nested list",
+			body:     "<ul><li>Actions<ul><li>Hosted runners</li></ul></li></ul>",
+			expected: "- Actions\n  - Hosted runners",
+		},
+		{
+			name:     "paragraphs",
+			body:     "<p>First paragraph</p><p>Second paragraph</p>",
+			expected: "First paragraph\nSecond paragraph",
+		},
+		{
+			name:     "unknown tags are dropped",
+			body:     `<span class="x">Text</span> <img src="x.png">`,
+			expected: "Text",
+		},
+		{
+			name:     "markdown bold and link",
+			body:     "**Update:** see [the blog](https://github.blog/post)",
+			expected: "Update: see the blog (https://github.blog/post)",
+		},
+		{
+			name:     "markdown list",
+			body:     "Affected services:\n- Actions\n* Pages",
+			expected: "Affected services:\n- Actions\n- Pages",
+		},
+		{
+			name:     "comparison is not a tag",
+			body:     "latency < 5s and 3 > 2",
+			expected: "latency < 5s and 3 > 2",
+		},
+		{
+			name:     "stray closing tag",
+			body:     "Done</strong></a></ul>",
+			expected: "Done",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := renderMarkup(tt.body, plainMarkup)
+			if result != tt.expected {
+				t.Errorf("renderMarkup(%q) = %q, expected %q", tt.body, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderMarkup_Terminal(t *testing.T) {
+	body := `<strong>Update:</strong> see <a href="https://github.blog">the <em>blog</em></a><ul><li>Actions</li></ul>`
+	expected := "\x1b[1mUpdate:\x1b[22m see \x1b]8;;https://github.blog\x1b\\the \x1b[3mblog\x1b[23m\x1b]8;;\x1b\\\n• Actions"
+
+	result := renderMarkup(body, terminalMarkup)
+	if result != expected {
+		t.Errorf("renderMarkup() = %q, expected %q", result, expected)
+	}
+	if stripAnsiCodes(result) != "Update: see the blog\n• Actions" {
+		t.Errorf("expected escape codes to strip cleanly, got %q", stripAnsiCodes(result))
+	}
+}
+
+func TestRenderMarkup_Markdown(t *testing.T) {
+	body := `<strong>Update:</strong> [1/2] see <a href="https://github.blog">the blog</a><ul><li>Actions | Pages</li></ul>`
+	expected := `**Update:** \[1/2\] see [the blog](https://github.blog)<br>• Actions \| Pages`
+
+	result := renderMarkup(body, markdownMarkup)
+	if result != expected {
+		t.Errorf("renderMarkup() = %q, expected %q", result, expected)
+	}
+}
+
+func TestRenderMarkup_StripsControlCharacters(t *testing.T) {
+	body := "a &#27;[2J b\x07 <a href=\"x&#7;y\">t</a> <a href=\"https://example.com/&#27;]8;;\">u</a>"
+
+	result := renderMarkup(body, terminalMarkup)
+	if strings.ContainsAny(result, "\x1b\x07\u009b") {
+		t.Errorf("expected control characters to be removed, got %q", result)
+	}
+	if result != "a [2J b t u" {
+		t.Errorf("renderMarkup() = %q, expected the unsafe links to become plain text", result)
+	}
+}
+
+func TestRenderMarkup_OnlyLinksHTTP(t *testing.T) {
+	tests := map[string]string{
+		`<a href="javascript:alert(1)">click</a>`:     "click",
+		`<a href="file:///etc/passwd">file</a>`:       "file",
+		`<a href="/incidents/123">relative</a>`:       "relative",
+		`<a href="http://example.com">insecure</a>`:   "insecure (http://example.com)",
+		`<a href="https://example.com/a b">space</a>`: "space",
+	}
+	for body, expected := range tests {
+		if result := renderMarkup(body, plainMarkup); result != expected {
+			t.Errorf("renderMarkup(%q) = %q, expected %q", body, result, expected)
+		}
+	}
+}
+
+func TestRenderMarkup_MarkdownEscapesLinkDestinations(t *testing.T) {
+	body := `<a href="https://en.wikipedia.org/wiki/Git_(software)">Git</a>`
+	expected := `[Git](https://en.wikipedia.org/wiki/Git_%28software%29)`
+
+	if result := renderMarkup(body, markdownMarkup); result != expected {
+		t.Errorf("renderMarkup() = %q, expected %q", result, expected)
+	}
+}
+
+func TestRenderMarkup_KeepsLineBreaksFromDecodedBody(t *testing.T) {
+	var update status.IncidentUpdate
+	if err := json.Unmarshal([]byte(`{"body":"First line<br />Second line","status":"investigating"}`), &update); err != nil {
+		t.Fatal(err)
+	}
+	if result := renderMarkup(update.Update, plainMarkup); result != "First line\nSecond line" {
+		t.Errorf("renderMarkup() = %q, expected the <br> to become a line break", result)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// clientFromFlags creates a client configured by the flags shared by every command: --timeout,
+// --retries and the network flags read by networkOptions
+func clientFromFlags(cmd *cobra.Command) (*status.Client, error) {
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, err
+	}
+	retries, err := cmd.Flags().GetInt("retries")
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := cmd.Flags().GetString("proxy")
+	if err != nil {
+		return nil, err
+	}
+	caFile, err := cmd.Flags().GetString("ca-file")
+	if err != nil {
+		return nil, err
+	}
+	certFile, err := cmd.Flags().GetString("client-cert")
+	if err != nil {
+		return nil, err
+	}
+	keyFile, err := cmd.Flags().GetString("client-key")
+	if err != nil {
+		return nil, err
+	}
+	options, err := networkOptions(proxy, caFile, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, status.WithTimeout(timeout), status.WithRetries(retries))
+	return status.NewClient(options...), nil
+}
+
+// networkOptions configures the client for the network it's on from the --proxy, --ca-file,
+// --client-cert and --client-key flags. Without a proxy Go's default applies, which like gh
+// honors HTTPS_PROXY and NO_PROXY. SSL_CERT_FILE is the default for --ca-file rather than being
+// left to Go, which ignores it on macOS.
+func networkOptions(proxy, caFile, certFile, keyFile string) ([]status.Option, error) {
+	var options []status.Option
+	if proxy != "" {
+		proxyURL, err := parseProxy(proxy)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, status.WithProxy(proxyURL))
+	}
+
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return options, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		roots, err := loadCAFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = roots
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("--client-cert and --client-key must be used together")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return append(options, status.WithTLSConfig(config)), nil
+}
+
+// parseProxy parses a proxy URL, assuming http:// when no scheme is given as curl does
+func parseProxy(proxy string) (*url.URL, error) {
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q, expected a URL such as http://proxy.example.com:3128", proxy)
+	}
+	return proxyURL, nil
+}
+
+// loadCAFile trusts the PEM encoded certificates in path in addition to the system's own, so a
+// proxy that intercepts TLS can be trusted without losing the usual CAs
+func loadCAFile(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the CA file: %w", err)
+	}
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in the CA file %s", path)
+	}
+	return roots, nil
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+func TestParseProxy(t *testing.T) {
+	tests := map[string]string{
+		"http://proxy.example.com:3128":   "http://proxy.example.com:3128",
+		"proxy.example.com:3128":          "http://proxy.example.com:3128",
+		"socks5://proxy.example.com:1080": "socks5://proxy.example.com:1080",
+	}
+	for proxy, expected := range tests {
+		proxyURL, err := parseProxy(proxy)
+		if err != nil {
+			t.Errorf("parseProxy(%q) returned error: %v", proxy, err)
+			continue
+		}
+		if proxyURL.String() != expected {
+			t.Errorf("parseProxy(%q) = %q, expected %q", proxy, proxyURL, expected)
+		}
+	}
+	if _, err := parseProxy("http://"); err == nil {
+		t.Error("expected a proxy without a host to be rejected")
+	}
+}
+
+func TestLoadCAFile(t *testing.T) {
+	svr := httptest.NewTLSServer(nil)
+	defer svr.Close()
+	dir := t.TempDir()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svr.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCAFile(caFile); err != nil {
+		t.Errorf("expected the CA file to load, got %v", err)
+	}
+
+	emptyFile := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(emptyFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCAFile(emptyFile); err == nil || !strings.Contains(err.Error(), "no PEM encoded certificates") {
+		t.Errorf("expected an error explaining the file has no certificates, got %v", err)
+	}
+
+	if _, err := loadCAFile(filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+}
+
+func TestNetworkOptions(t *testing.T) {
+	options, err := networkOptions("", "", "", "")
+	if err != nil || len(options) != 0 {
+		t.Errorf("expected no options without flags, got %d options and error %v", len(options), err)
+	}
+
+	options, err = networkOptions("proxy.example.com:3128", "", "", "")
+	if err != nil || len(options) != 1 {
+		t.Errorf("expected a proxy option, got %d options and error %v", len(options), err)
+	}
+
+	if _, err := networkOptions("", "", "client.pem", ""); err == nil || !strings.Contains(err.Error(), "--client-key") {
+		t.Errorf("expected an error when --client-cert is used without --client-key, got %v", err)
+	}
+}
+
+func TestNetworkOptions_CAFileAndClientCert(t *testing.T) {
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"components":[{"id":"1","name":"Git Operations","status":"operational"}]}`))
+	}))
+	svr.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	svr.StartTLS()
+	defer svr.Close()
+
+	// The test server's own certificate doubles as the CA and the client certificate
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	serverCert := svr.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Certificate[0]}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests go through a client of the test's own rather than the shared default client
+	httpClient := &http.Client{}
+	t.Cleanup(httpClient.CloseIdleConnections)
+
+	options, err := networkOptions("", certFile, certFile, keyFile)
+	if err != nil {
+		t.Fatalf("networkOptions() returned error: %v", err)
+	}
+	client := status.NewClient(append(options, status.WithHTTPClient(httpClient), status.WithBaseURL(svr.URL))...)
+	summary, _, err := client.Poll()
+	if err != nil {
+		t.Fatalf("expected the CA file to be trusted and the client certificate sent, got %v", err)
+	}
+	if len(summary.Components) != 1 {
+		t.Errorf("expected 1 component, got %d", len(summary.Components))
+	}
+
+	options, err = networkOptions("", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = status.NewClient(append(options, status.WithHTTPClient(httpClient), status.WithBaseURL(svr.URL))...).Poll()
+	if !errors.Is(err, status.ErrCertificate) {
+		t.Errorf("expected a certificate error without the CA file, got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+	"golang.org/x/term"
+)
+
+// Snapshot is everything a Renderer needs to know to draw the current state
+type Snapshot struct {
+	Summary     *status.SystemStatus // most recent summary, nil until the first successful poll
+	Err         error                // error from the most recent poll, if it failed
+	LastUpdate  time.Time            // when the most recent successful poll completed
+	Watch       bool                 // whether the status is being polled continuously
+	History     componentHistory     // status of each component at every poll this session
+	Log         eventLog             // every change observed this session
+	ShowLog     bool                 // whether the session log should be shown
+	Changes     highlights           // components and incident updates that changed recently
+	NotModified bool                 // whether the most recent poll found nothing had changed
+	StatusCode  int                  // HTTP status code of the most recent response, 0 if none was received
+	Latency     time.Duration        // how long the most recent poll took to respond
+	ErrorCount  int                  // how many polls in a row have failed
+	Paused      bool                 // whether polling on the interval is paused
+	Interval    time.Duration        // how often the status is polled
+	NextPoll    time.Time            // when the next poll is due, zero if unknown or paused
+}
+
+// Renderer turns a snapshot into output for a screen that is width columns by height rows
+type Renderer interface {
+	Render(snap Snapshot, width, height int) string
+}
+
+// renderOptions are the user's preferences that apply to every renderer
+type renderOptions struct {
+	theme statusTheme
+	times timeFormatter
+}
+
+// newRenderOptions builds renderOptions from the names given on the command line
+func newRenderOptions(themeName string, symbolsName string, timeZone string, timeFormat string) (renderOptions, error) {
+	theme, err := newStatusTheme(themeName, symbolsName)
+	if err != nil {
+		return renderOptions{}, err
+	}
+	times, err := newTimeFormatter(timeZone, timeFormat)
+	if err != nil {
+		return renderOptions{}, err
+	}
+	return renderOptions{theme: theme, times: times}, nil
+}
+
+// renderers create the renderer for each output format that can be selected with --format
+var renderers = map[string]func(options renderOptions) Renderer{
+	"tui":      func(options renderOptions) Renderer { return tuiRenderer{options: options} },
+	"plain":    func(options renderOptions) Renderer { return plainRenderer{options: options} },
+	"json":     func(options renderOptions) Renderer { return jsonRenderer{times: options.times} },
+	"markdown": func(options renderOptions) Renderer { return markdownRenderer{options: options} },
+}
+
+// formatNames returns the names of every output format, sorted for help text
+func formatNames() []string {
+	return sortedKeys(renderers)
+}
+
+// display shows rendered output, either by redrawing an area of the terminal or by appending
+// to a stream such as a pipe or a file
+type display interface {
+	Update(text ...any)
+	Clear()
+}
+
+// streamDisplay appends each rendered output to a writer
+type streamDisplay struct {
+	w io.Writer
+}
+
+func (s streamDisplay) Update(text ...any) {
+	output := fmt.Sprint(text...)
+	if !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+	_, _ = io.WriteString(s.w, output)
+}
+
+// Clear does nothing since output that has been streamed can't be taken back
+func (s streamDisplay) Clear() {}
+
+// terminalSize returns the size of the terminal attached to stdout, or 0x0 if stdout is not
+// a terminal, in which case renderers should not wrap or pad their output
+func terminalSize() (width, height int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0, 0
+	}
+	return width, height
+}
+
+// stdoutIsTerminal reports whether stdout is a terminal rather than a pipe or a file
+func stdoutIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// colorDisabled reports whether the user asked for no color, see https://no-color.org
+func colorDisabled() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// selectFormat decides which output format to use. The plain format is used when --plain is
+// passed, or when stdout isn't a terminal and no format was explicitly requested.
+func selectFormat(format string, formatSet bool, plain bool, isTerminal bool) string {
+	if plain || (!formatSet && !isTerminal) {
+		return "plain"
+	}
+	return format
+}
+
+// visibleComponents returns the components worth showing to users
+func visibleComponents(summary *status.SystemStatus) []status.Components {
+	components := make([]status.Components, 0, len(summary.Components))
+	for _, component := range summary.Components {
+		if component.ID == IGNORE_GHSTATUS_COMPONENTID {
+			continue
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// statusLabel returns the human readable name of a component status
+func statusLabel(componentStatus status.ComponentStatus) string {
+	switch componentStatus {
+	case status.COMPONENT_OPERATIONAL:
+		return "Operational"
+	case status.COMPONENT_UNDER_MAINTENANCE:
+		return "Under Maintenance"
+	case status.COMPONENT_DEGREDADED_PERFORMANCE:
+		return "Degraded Performance"
+	case status.COMPONENT_PARTIAL_OUTAGE:
+		return "Partial Outage"
+	case status.COMPONENT_MAJOR_OUTAGE:
+		return "Major Outage"
+	default:
+		return string(componentStatus)
+	}
+}
+
+// indicatorStatus maps the overall status indicator to the component status whose symbol and
+// colors it is shown with
+func indicatorStatus(indicator status.Indicator) status.ComponentStatus {
+	switch indicator {
+	case status.INDICATOR_NONE:
+		return status.COMPONENT_OPERATIONAL
+	case status.INDICATOR_MINOR:
+		return status.COMPONENT_DEGREDADED_PERFORMANCE
+	case status.INDICATOR_MAJOR:
+		return status.COMPONENT_PARTIAL_OUTAGE
+	case status.INDICATOR_CRITICAL:
+		return status.COMPONENT_MAJOR_OUTAGE
+	default:
+		return status.ComponentStatus(indicator)
+	}
+}
+
+// incidentStatusLabel returns the human readable name of an incident status
+func incidentStatusLabel(incidentStatus status.IncidentStatus) string {
+	switch incidentStatus {
+	case status.INCIDENT_INVESTIGATING:
+		return "Investigating"
+	case status.INCIDENT_IDENTIFIED:
+		return "Identified"
+	case status.INCIDENT_MONITORING:
+		return "Monitoring"
+	case status.INCIDENT_RESOLVED:
+		return "Resolved"
+	case status.INCIDENT_POSTMORTEM:
+		return "Postmortem"
+	default:
+		return string(incidentStatus)
+	}
+}
+
+// incidentURL returns the link to an incident on githubstatus.com
+func incidentURL(incidentID string) string {
+	return fmt.Sprintf("https://www.githubstatus.com/incidents/%s", incidentID)
+}
+
+// errorMessage explains that the most recent poll failed
+func errorMessage(err error) string {
+	return fmt.Sprintf("%s\nError Message: %s", errorReason(err), err.Error())
+}
+
+// errorReason says in plain words why a poll failed and what can be done about it
+func errorReason(err error) string {
+	var serverErr status.ErrServerError
+	switch {
+	case errors.Is(err, status.ErrRateLimited):
+		return "githubstatus.com is limiting how often the status can be retrieved, press '-' in watch mode to poll less often, which lengthens the interval."
+	case errors.As(err, &serverErr) && serverErr.Code >= http.StatusInternalServerError:
+		return "githubstatus.com is having problems of its own, if this is in watch mode, it will try again on the next poll."
+	case errors.Is(err, status.ErrCertificate):
+		return "Unable to verify the certificate of githubstatus.com. If a proxy on your network intercepts TLS, trust its CA with --ca-file or SSL_CERT_FILE."
+	case errors.Is(err, status.ErrNetwork):
+		return "Unable to reach githubstatus.com, check your network connection. If this is in watch mode, it will try again on the next poll."
+	case errors.Is(err, status.ErrDecode):
+		return "githubstatus.com responded with something other than the status, a proxy or captive portal may be getting in the way."
+	default:
+		return "Error retrieving current GitHub status, if this is in watch mode, it will try again on the next poll."
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+//go:embed templates/status.html
+var htmlTemplateSource string
+
+var htmlTemplate = template.Must(template.New("status").Parse(htmlTemplateSource))
+
+// timelineDays is how many days of incident history the HTML timeline covers
+const timelineDays = 90
+
+// htmlRenderer writes a self-contained HTML page, including a timeline of the incident history
+// when one was retrieved
+type htmlRenderer struct {
+	options renderOptions
+	history []status.Incidents
+}
+
+// htmlPage is the data passed to the HTML template
+type htmlPage struct {
+	Generated     string
+	Error         string
+	Status        *htmlStatus
+	Components    []htmlComponent
+	Incidents     []htmlIncident
+	Timeline      []htmlDay
+	TimelineWidth int
+	TimelineStart string
+	TimelineEnd   string
+}
+
+// htmlStatus is the overall status of the page, Class is its indicator
+type htmlStatus struct {
+	Description string
+	Class       string
+}
+
+type htmlComponent struct {
+	Name  string
+	Label string
+	Class string
+}
+
+type htmlIncident struct {
+	Name    string
+	URL     string
+	Status  string
+	Impact  string
+	Updates []htmlUpdate
+}
+
+type htmlUpdate struct {
+	Status string
+	Time   string
+	Body   template.HTML // escaped by htmlMarkup, keeping the formatting of the update
+}
+
+// htmlDay is a single bar of the incident history timeline
+type htmlDay struct {
+	X     int
+	Class string
+	Title string
+}
+
+func (h htmlRenderer) Render(snap Snapshot, _, _ int) string {
+	page := htmlPage{
+		Generated: h.options.times.timestamp(snap.LastUpdate),
+	}
+	if snap.Err != nil {
+		page.Error = snap.Err.Error()
+	}
+	if snap.Summary != nil {
+		overall := snap.Summary.Overall()
+		page.Status = &htmlStatus{Description: overall.Description, Class: string(overall.Indicator)}
+		for _, component := range visibleComponents(snap.Summary) {
+			page.Components = append(page.Components, htmlComponent{
+				Name:  component.Component,
+				Label: statusLabel(component.Status),
+				Class: string(component.Status),
+			})
+		}
+		for _, incident := range snap.Summary.ActiveIncidents() {
+			page.Incidents = append(page.Incidents, newHTMLIncident(incident, h.options.times))
+		}
+	}
+	if len(h.history) > 0 {
+		// Days start at midnight in the chosen time zone
+		now := h.options.times.in(snap.LastUpdate)
+		page.Timeline = incidentTimeline(h.history, now)
+		page.TimelineWidth = timelineDays * 10
+		page.TimelineStart = now.AddDate(0, 0, -(timelineDays - 1)).Format("Jan 2")
+		page.TimelineEnd = "Today"
+	}
+
+	var output strings.Builder
+	if err := htmlTemplate.Execute(&output, page); err != nil {
+		// Only possible if the template doesn't match htmlPage, which would be a bug
+		return fmt.Sprintf("<!DOCTYPE html><p>Unable to render status: %s</p>", template.HTMLEscapeString(err.Error()))
+	}
+	return output.String()
+}
+
+func newHTMLIncident(incident status.Incidents, times timeFormatter) htmlIncident {
+	name := incident.Name
+	if name == "" {
+		name = "Incident " + incident.ID
+	}
+	result := htmlIncident{
+		Name:   name,
+		URL:    incidentURL(incident.ID),
+		Status: incidentStatusLabel(incident.Status),
+		Impact: incident.Impact,
+	}
+	for _, update := range incident.IncidentUpdates {
+		result.Updates = append(result.Updates, htmlUpdate{
+			Status: incidentStatusLabel(update.Status),
+			Time:   times.statusTime(update.Timestamp),
+			Body:   template.HTML(renderMarkup(update.Update, htmlMarkup)),
+		})
+	}
+	return result
+}
+
+// impactSeverity orders the impact of an incident from none to critical
+func impactSeverity(impact string) int {
+	switch impact {
+	case "maintenance":
+		return 1
+	case "minor":
+		return 2
+	case "major":
+		return 3
+	case "critical":
+		return 4
+	default:
+		return 0
+	}
+}
+
+// incidentTimeline builds one bar per day for the last timelineDays days up to and including
+// the day of now, colored by the worst impact of any incident open during that day
+func incidentTimeline(history []status.Incidents, now time.Time) []htmlDay {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days := make([]htmlDay, 0, timelineDays)
+	for i := 0; i < timelineDays; i++ {
+		start := today.AddDate(0, 0, i-(timelineDays-1))
+		end := start.AddDate(0, 0, 1)
+
+		worst := "none"
+		var names []string
+		for _, incident := range history {
+			if incident.CreatedAt == nil || incident.CreatedAt.Time == nil {
+				continue
+			}
+			resolved := now
+			if incident.ResolvedAt != nil && incident.ResolvedAt.Time != nil {
+				resolved = *incident.ResolvedAt.Time
+			}
+			if !incident.CreatedAt.Before(end) || resolved.Before(start) {
+				continue
+			}
+			names = append(names, incident.Name)
+			if impactSeverity(incident.Impact) > impactSeverity(worst) {
+				worst = incident.Impact
+			}
+		}
+
+		title := start.Format("Jan 2") + ": No incidents"
+		if len(names) > 0 {
+			title = start.Format("Jan 2") + ": " + strings.Join(names, ", ")
+		}
+		days = append(days, htmlDay{X: i * 10, Class: worst, Title: title})
+	}
+	return days
+}
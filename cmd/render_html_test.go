@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// historyIncident creates a resolved incident for the timeline
+func historyIncident(name string, impact string, created time.Time, resolved time.Time) status.Incidents {
+	return status.Incidents{
+		Name:       name,
+		Impact:     impact,
+		Status:     status.INCIDENT_RESOLVED,
+		CreatedAt:  &status.Time{Time: &created},
+		ResolvedAt: &status.Time{Time: &resolved},
+	}
+}
+
+func TestHTMLRenderer_Golden(t *testing.T) {
+	history := []status.Incidents{
+		historyIncident("Incident with Pages", "major",
+			time.Date(2024, 1, 10, 22, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 11, 2, 0, 0, 0, time.UTC)),
+	}
+	assertGolden(t, "html", htmlRenderer{options: testRenderOptions(), history: history}.Render(goldenSnapshot(), 0, 0))
+}
+
+func TestHTMLRenderer_EscapesContent(t *testing.T) {
+	snap := goldenSnapshot()
+	snap.Summary.Components[0].Component = "<script>alert(1)</script>"
+
+	result := htmlRenderer{}.Render(snap, 0, 0)
+	if strings.Contains(result, "<script>") {
+		t.Error("expected component names to be escaped")
+	}
+	if strings.Contains(result, "<svg") {
+		t.Error("expected no timeline without incident history")
+	}
+}
+
+func TestIncidentTimeline(t *testing.T) {
+	now := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	history := []status.Incidents{
+		historyIncident("Minor incident", "minor",
+			time.Date(2024, 1, 10, 22, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 11, 2, 0, 0, 0, time.UTC)),
+		historyIncident("Critical incident", "critical",
+			time.Date(2024, 1, 11, 1, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC)),
+		historyIncident("Too old", "critical",
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)),
+	}
+
+	days := incidentTimeline(history, now)
+	if len(days) != timelineDays {
+		t.Fatalf("expected %d days, got %d", timelineDays, len(days))
+	}
+	// The last bar is today, so Jan 10 and Jan 11 are 5 and 4 days before it
+	jan10 := days[timelineDays-6]
+	jan11 := days[timelineDays-5]
+	if jan10.Class != "minor" || !strings.HasPrefix(jan10.Title, "Jan 10: Minor incident") {
+		t.Errorf("unexpected Jan 10 bar %+v", jan10)
+	}
+	if jan11.Class != "critical" || jan11.Title != "Jan 11: Minor incident, Critical incident" {
+		t.Errorf("unexpected Jan 11 bar %+v", jan11)
+	}
+	for i, day := range days {
+		if i != timelineDays-6 && i != timelineDays-5 && day.Class != "none" {
+			t.Errorf("expected no incidents on day %d, got %+v", i, day)
+		}
+	}
+}
+
+func TestHTMLRenderer_KeepsUpdateFormatting(t *testing.T) {
+	snap := goldenSnapshot()
+	snap.Summary.Incidents[0].IncidentUpdates[0].Update = `<strong>Update:</strong> see <a href="https://github.blog">the blog</a><br>Fixed <script>alert(1)</script> &amp; more`
+
+	result := htmlRenderer{options: testRenderOptions()}.Render(snap, 0, 0)
+	expected := `<strong>Update:</strong> see <a href="https://github.blog">the blog</a><br>Fixed alert(1) &amp; more`
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected the update to keep its formatting as %q, got:\n%s", expected, result)
+	}
+	if strings.Contains(result, "<script>") {
+		t.Error("expected unknown tags in the update to be dropped")
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// jsonRenderer writes the snapshot as JSON for scripts to consume, with the last update in the
+// time zone chosen by --tz but always in RFC 3339 whatever --time-format says
+type jsonRenderer struct {
+	times timeFormatter
+}
+
+// jsonOutput is the document written by jsonRenderer
+type jsonOutput struct {
+	LastUpdated time.Time           `json:"last_updated"`
+	Error       string              `json:"error,omitempty"`
+	Status      *status.PageStatus  `json:"status,omitempty"`
+	Components  []status.Components `json:"components"`
+	Incidents   []status.Incidents  `json:"incidents"`
+}
+
+func (r jsonRenderer) Render(snap Snapshot, _, _ int) string {
+	output := jsonOutput{
+		LastUpdated: r.times.in(snap.LastUpdate),
+		Components:  []status.Components{},
+		Incidents:   []status.Incidents{},
+	}
+	if snap.Err != nil {
+		output.Error = snap.Err.Error()
+	}
+	if snap.Summary != nil {
+		overall := snap.Summary.Overall()
+		output.Status = &overall
+		output.Components = visibleComponents(snap.Summary)
+		if snap.Summary.Incidents != nil {
+			output.Incidents = snap.Summary.Incidents
+		}
+	}
+	result, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		// Only possible if a type in the summary can't be marshalled, which would be a bug
+		return `{"error": "unable to encode status as JSON"}`
+	}
+	return string(result) + "\n"
+}
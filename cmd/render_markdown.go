@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// markdownRenderer writes GitHub flavored Markdown for pasting into issues and pull requests
+type markdownRenderer struct {
+	options renderOptions
+}
+
+// markdownEscaper escapes the characters that would otherwise break a table or a link
+var markdownEscaper = strings.NewReplacer(
+	"|", `\|`,
+	"[", `\[`,
+	"]", `\]`,
+	"\r\n", " ",
+	"\n", " ",
+)
+
+func (r markdownRenderer) Render(snap Snapshot, _, _ int) string {
+	var output strings.Builder
+	output.WriteString("## GitHub Status\n\n")
+	fmt.Fprintf(&output, "_Last updated %s_\n", r.options.times.clock(snap.LastUpdate))
+
+	if snap.Err != nil {
+		fmt.Fprintf(&output, "\n> [!WARNING]\n> Unable to retrieve the current status: %s\n", markdownEscaper.Replace(snap.Err.Error()))
+		if snap.Summary != nil {
+			output.WriteString(">\n> The status below is from the last time it was retrieved.\n")
+		}
+	}
+	if snap.Summary == nil {
+		return output.String()
+	}
+
+	overall := snap.Summary.Overall()
+	fmt.Fprintf(&output, "\n**%s%s**\n", r.options.theme.symbol(indicatorStatus(overall.Indicator)), markdownEscaper.Replace(overall.Description))
+
+	output.WriteString("\n| Component | Status |\n| --- | --- |\n")
+	for _, component := range visibleComponents(snap.Summary) {
+		fmt.Fprintf(&output, "| %s | %s%s |\n", markdownEscaper.Replace(component.Component), r.options.theme.symbol(component.Status), statusLabel(component.Status))
+	}
+
+	incidents := snap.Summary.ActiveIncidents()
+	if len(incidents) == 0 {
+		output.WriteString("\nNo active incidents.\n")
+		return output.String()
+	}
+	output.WriteString("\n### Active Incidents\n")
+	for _, incident := range incidents {
+		writeMarkdownIncident(&output, incident, r.options.times)
+	}
+	return output.String()
+}
+
+// writeMarkdownIncident writes an incident's linked title followed by its update timeline
+func writeMarkdownIncident(output *strings.Builder, incident status.Incidents, times timeFormatter) {
+	name := incident.Name
+	if name == "" {
+		name = "Incident " + incident.ID
+	}
+	fmt.Fprintf(output, "\n#### [%s](%s)\n\n", markdownEscaper.Replace(name), incidentURL(incident.ID))
+
+	fmt.Fprintf(output, "**Status:** %s", incidentStatusLabel(incident.Status))
+	if incident.Impact != "" {
+		fmt.Fprintf(output, " · **Impact:** %s", incident.Impact)
+	}
+	output.WriteString("\n\n")
+
+	for _, update := range incident.IncidentUpdates {
+		fmt.Fprintf(output, "- **%s** %s - %s\n",
+			incidentStatusLabel(update.Status),
+			times.statusTime(update.Timestamp),
+			renderMarkup(update.Update, markdownMarkup))
+	}
+}
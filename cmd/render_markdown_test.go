@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+func TestMarkdownRenderer_EscapesTableCells(t *testing.T) {
+	snap := goldenSnapshot()
+	snap.Summary.Components = []status.Components{
+		{ID: "comp1", Component: "Git | SSH", Status: status.COMPONENT_OPERATIONAL},
+	}
+
+	result := markdownRenderer{}.Render(snap, 0, 0)
+	if !strings.Contains(result, `| Git \| SSH | Operational |`) {
+		t.Errorf("expected pipe in component name to be escaped, got:\n%s", result)
+	}
+}
+
+func TestMarkdownRenderer_OnlyActiveIncidents(t *testing.T) {
+	snap := goldenSnapshot()
+	snap.Summary.Incidents = append(snap.Summary.Incidents, status.Incidents{
+		ID:     "resolved456",
+		Name:   "Resolved incident",
+		Status: status.INCIDENT_RESOLVED,
+	})
+
+	result := markdownRenderer{}.Render(snap, 0, 0)
+	if strings.Contains(result, "Resolved incident") {
+		t.Error("expected resolved incidents to be left out")
+	}
+	if !strings.Contains(result, "[Disruption with some GitHub services](https://www.githubstatus.com/incidents/incident123)") {
+		t.Error("expected the active incident title to link to the incident")
+	}
+}
+
+func TestMarkdownRenderer_NoIncidents(t *testing.T) {
+	snap := goldenSnapshot()
+	snap.Summary.Incidents = nil
+
+	result := markdownRenderer{}.Render(snap, 0, 0)
+	if !strings.Contains(result, "No active incidents.") {
+		t.Errorf("expected a note that there are no incidents, got:\n%s", result)
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// plainRenderer writes an uncolored report that reads well in logs and works with grep. Lines
+// are only wrapped when writing to a terminal, a width of zero leaves them whole.
+type plainRenderer struct {
+	options renderOptions
+}
+
+func (r plainRenderer) Render(snap Snapshot, width, _ int) string {
+	var output strings.Builder
+	fmt.Fprintf(&output, "Last Updated %s\n", r.options.times.clock(snap.LastUpdate))
+
+	if snap.Err != nil {
+		output.WriteString("\n")
+		output.WriteString(errorMessage(snap.Err))
+		output.WriteString("\n")
+	}
+	if snap.Summary == nil {
+		return output.String()
+	}
+	if snap.Err != nil {
+		fmt.Fprintf(&output, "\nShowing the status from %s\n", r.options.times.clock(snap.LastUpdate))
+	}
+
+	overall := snap.Summary.Overall()
+	fmt.Fprintf(&output, "\n%s%s\n", r.options.theme.symbol(indicatorStatus(overall.Indicator)), overall.Description)
+
+	output.WriteString("\nSystem Status\n")
+	for _, component := range visibleComponents(snap.Summary) {
+		fmt.Fprintf(&output, "%s%s - %s\n", r.options.theme.symbol(component.Status), component.Component, statusLabel(component.Status))
+	}
+
+	incidents := snap.Summary.Incidents
+	if len(incidents) > 0 {
+		fmt.Fprintf(&output, "\nIncident Updates %s\n", incidentURL(incidents[0].ID))
+		for _, update := range incidents[0].IncidentUpdates {
+			line := fmt.Sprintf("Updated %s - %s", r.options.times.statusTime(update.Timestamp), renderMarkup(update.Update, plainMarkup))
+			output.WriteString(wrapText(line, width))
+			output.WriteString("\n")
+		}
+	}
+	return output.String()
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPlainRenderer_DoesNotWrapWithoutTerminal(t *testing.T) {
+	result := plainRenderer{options: testRenderOptions()}.Render(goldenSnapshot(), 0, 0)
+
+	expected := "Updated 2024-01-15 12:45 PM - We have identified the cause of the degraded performance in Actions and are rolling out a fix to all regions.\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected incident update on a single line, got:\n%s", result)
+	}
+}
+
+func TestPlainRenderer_HasNoAnsiCodesOrPadding(t *testing.T) {
+	result := plainRenderer{}.Render(goldenSnapshot(), 80, 24)
+
+	if result != stripAnsiCodes(result) {
+		t.Error("expected plain output to contain no ANSI codes")
+	}
+	for _, line := range strings.Split(result, "\n") {
+		if strings.HasSuffix(line, " ") {
+			t.Errorf("expected no trailing padding, got %q", line)
+		}
+	}
+	if strings.HasSuffix(result, "\n\n") {
+		t.Error("expected output not to be padded to the terminal height")
+	}
+}
+
+func TestPlainRenderer_KeepsStaleDataOnError(t *testing.T) {
+	snap := goldenSnapshot()
+	snap.Err = errors.New("connection refused")
+	result := plainRenderer{options: testRenderOptions()}.Render(snap, 80, 24)
+
+	for _, expected := range []string{"Error Message: connection refused", "Showing the status from", "Actions - Partial Outage"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, result)
+		}
+	}
+}
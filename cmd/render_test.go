@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// assertGolden compares actual with testdata/<name>.golden, rewriting the file instead when
+// the tests are run with -update
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Fatalf("unable to update golden file %s: %v", path, err)
+		}
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read golden file %s: %v", path, err)
+	}
+	if string(expected) != actual {
+		t.Errorf("output does not match %s\nexpected:\n%s\nactual:\n%s", path, expected, actual)
+	}
+}
+
+// testRenderOptions are the options used when no flags are passed, in UTC so output is reproducible
+func testRenderOptions() renderOptions {
+	options, err := newRenderOptions(defaultTheme, "", "UTC", defaultTimeFormat)
+	if err != nil {
+		panic(err)
+	}
+	return options
+}
+
+// goldenSnapshot is a snapshot exercising every part of the renderers
+func goldenSnapshot() Snapshot {
+	firstUpdate := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	secondUpdate := time.Date(2024, 1, 15, 12, 45, 0, 0, time.UTC)
+	return Snapshot{
+		LastUpdate: time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC),
+		Summary: &status.SystemStatus{
+			Status: status.PageStatus{Indicator: status.INDICATOR_MAJOR, Description: "Partial System Outage"},
+			Components: []status.Components{
+				{ID: "comp1", Component: "Git Operations", Status: status.COMPONENT_OPERATIONAL},
+				{ID: "comp2", Component: "API Requests", Status: status.COMPONENT_DEGREDADED_PERFORMANCE},
+				{ID: IGNORE_GHSTATUS_COMPONENTID, Component: "Visit www.githubstatus.com for more information", Status: status.COMPONENT_OPERATIONAL},
+				{ID: "comp3", Component: "Actions", Status: status.COMPONENT_PARTIAL_OUTAGE},
+				{ID: "comp4", Component: "Pages", Status: status.COMPONENT_MAJOR_OUTAGE},
+			},
+			Incidents: []status.Incidents{
+				{
+					ID:     "incident123",
+					Name:   "Disruption with some GitHub services",
+					Impact: "minor",
+					Status: status.INCIDENT_IDENTIFIED,
+					IncidentUpdates: []status.IncidentUpdate{
+						{
+							Status:    status.INCIDENT_IDENTIFIED,
+							Update:    "We have identified the cause of the degraded performance in Actions and are rolling out a fix to all regions.",
+							Timestamp: &status.Time{Time: &secondUpdate},
+						},
+						{
+							Status:    status.INCIDENT_INVESTIGATING,
+							Update:    "We are investigating reports of degraded performance.",
+							Timestamp: &status.Time{Time: &firstUpdate},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderers_Golden(t *testing.T) {
+	errSnapshot := Snapshot{
+		LastUpdate: time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC),
+		Err:        errors.New("connection refused"),
+	}
+	for _, format := range []string{"plain", "json", "markdown"} {
+		t.Run(format, func(t *testing.T) {
+			renderer := renderers[format](testRenderOptions())
+			assertGolden(t, format, renderer.Render(goldenSnapshot(), 60, 24))
+			assertGolden(t, format+"_error", renderer.Render(errSnapshot, 60, 24))
+		})
+	}
+}
+
+func TestJSONRenderer_TimeZone(t *testing.T) {
+	options, err := newRenderOptions(defaultTheme, "", "America/New_York", "24h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap := Snapshot{LastUpdate: time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)}
+	result := renderers["json"](options).Render(snap, 0, 0)
+	if !strings.Contains(result, `"last_updated": "2024-01-15T09:30:00-05:00"`) {
+		t.Errorf("expected the last update in the chosen time zone as RFC 3339, got:\n%s", result)
+	}
+}
+
+func TestFormatNames(t *testing.T) {
+	names := formatNames()
+	if len(names) != len(renderers) {
+		t.Fatalf("expected %d format names, got %d", len(renderers), len(names))
+	}
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Errorf("expected format names to be sorted, got %v", names)
+		}
+	}
+}
+
+func TestVisibleComponents(t *testing.T) {
+	components := visibleComponents(goldenSnapshot().Summary)
+	if len(components) != 4 {
+		t.Fatalf("expected 4 visible components, got %d", len(components))
+	}
+	for _, component := range components {
+		if component.ID == IGNORE_GHSTATUS_COMPONENTID {
+			t.Error("expected the githubstatus.com component to be hidden")
+		}
+	}
+}
+
+func TestSelectFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		formatSet  bool
+		plain      bool
+		isTerminal bool
+		expected   string
+	}{
+		{name: "default on a terminal", format: "tui", isTerminal: true, expected: "tui"},
+		{name: "default when piped", format: "tui", expected: "plain"},
+		{name: "explicit format when piped", format: "tui", formatSet: true, expected: "tui"},
+		{name: "explicit json on a terminal", format: "json", formatSet: true, isTerminal: true, expected: "json"},
+		{name: "plain flag on a terminal", format: "tui", plain: true, isTerminal: true, expected: "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := selectFormat(tt.format, tt.formatSet, tt.plain, tt.isTerminal)
+			if result != tt.expected {
+				t.Errorf("selectFormat() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColorDisabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if colorDisabled() {
+		t.Error("expected color to be enabled when NO_COLOR is empty")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if !colorDisabled() {
+		t.Error("expected color to be disabled when NO_COLOR is set")
+	}
+}
+
+func TestIndicatorStatus(t *testing.T) {
+	tests := map[status.Indicator]status.ComponentStatus{
+		status.INDICATOR_NONE:     status.COMPONENT_OPERATIONAL,
+		status.INDICATOR_MINOR:    status.COMPONENT_DEGREDADED_PERFORMANCE,
+		status.INDICATOR_MAJOR:    status.COMPONENT_PARTIAL_OUTAGE,
+		status.INDICATOR_CRITICAL: status.COMPONENT_MAJOR_OUTAGE,
+	}
+	for indicator, expected := range tests {
+		if result := indicatorStatus(indicator); result != expected {
+			t.Errorf("indicatorStatus(%q) = %q, expected %q", indicator, result, expected)
+		}
+	}
+	theme, err := newStatusTheme(defaultTheme, "unicode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if symbol := theme.symbol(indicatorStatus("maintenance")); symbol != unknownSymbol+" " {
+		t.Errorf("expected an unknown indicator to use the unknown symbol, got %q", symbol)
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected string
+	}{
+		"rate limited":      {err: status.ErrRateLimited, expected: "poll less often"},
+		"server error":      {err: status.ErrServerError{Code: 502}, expected: "having problems of its own"},
+		"network error":     {err: fmt.Errorf("%w: connection refused", status.ErrNetwork), expected: "check your network connection"},
+		"decode error":      {err: fmt.Errorf("%w: invalid character", status.ErrDecode), expected: "captive portal"},
+		"certificate error": {err: fmt.Errorf("%w: x509: certificate signed by unknown authority", status.ErrCertificate), expected: "--ca-file"},
+		"other error":       {err: errors.New("boom"), expected: "Error retrieving current GitHub status"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			message := errorMessage(tt.err)
+			if !strings.Contains(message, tt.expected) {
+				t.Errorf("expected %q to contain %q", message, tt.expected)
+			}
+			if !strings.Contains(message, "Error Message: "+tt.err.Error()) {
+				t.Errorf("expected %q to include the error", message)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// highlightStyle marks what changed since the last few polls
+var highlightStyle = pterm.NewStyle(pterm.Reverse, pterm.Bold)
+
+// tuiRenderer draws colored boxes that fill the whole terminal
+type tuiRenderer struct {
+	options renderOptions
+}
+
+// Render generates the UI output based on current data and terminal dimensions
+func (r tuiRenderer) Render(snap Snapshot, termWidth, termHeight int) string {
+	if termWidth <= 0 || termHeight <= 0 {
+		// Fallback to default values if terminal size can't be determined
+		termWidth = 80
+		termHeight = 24
+	}
+
+	updateTime := pterm.DefaultBasicText.Sprintf("Last Updated %s \n", r.options.times.clock(snap.LastUpdate))
+
+	// In watch mode the poll status and help text are shown at the bottom
+	var footer string
+	if snap.Watch {
+		footer = r.footer(snap, termWidth-screenMargin)
+	}
+
+	// Leave room for the last updated line and in watch mode the footer below a blank line.
+	// On the shortest terminals even the footer is cut down to leave a line for the status.
+	height := termHeight - 1
+	if snap.Watch {
+		footerLines := strings.Split(footer, "\n")
+		if len(footerLines) > termHeight-3 {
+			footerLines = footerLines[:max(termHeight-3, 1)]
+			footer = strings.Join(footerLines, "\n")
+		}
+		height -= len(footerLines) + 1
+	}
+	height = max(height, 1)
+
+	var body string
+	if snap.Summary == nil {
+		if snap.Err != nil {
+			body = wrapText(errorMessage(snap.Err), termWidth-screenMargin)
+		}
+	} else {
+		body = r.content(snap, termWidth-screenMargin, height)
+	}
+	bodyLines := strings.Split(body, "\n")
+	if len(bodyLines) > height {
+		// Only reached when even the smallest layout doesn't fit, cutting it short is all that's left
+		bodyLines = bodyLines[:height]
+	}
+
+	var output strings.Builder
+	output.WriteString(updateTime)
+	output.WriteString(strings.Join(bodyLines, "\n"))
+	if snap.Watch {
+		// Pad so the footer sits at the bottom, always below a blank line
+		output.WriteString(strings.Repeat("\n", height-len(bodyLines)+2))
+		output.WriteString(footer)
+	} else if padding := termHeight - 1 - len(bodyLines); padding > 0 {
+		output.WriteString(strings.Repeat("\n", padding))
+	}
+
+	return output.String()
+}
+
+// content arranges the status to fit in width and height: a warning if the data is stale, the
+// overall status, the boxes and the session log when shown. When that is too tall the warning is
+// cut down to its first line and then the banner is dropped to leave the boxes more room.
+func (r tuiRenderer) content(snap Snapshot, width, height int) string {
+	var warning []string
+	if snap.Err != nil {
+		// Keep showing the last known status rather than blanking it over a failed poll
+		warning = r.staleWarning(snap, width)
+	}
+	showBanner := true
+	for {
+		text := r.arrange(snap, warning, showBanner, width, height)
+		if strings.Count(text, "\n")+1 <= height {
+			return text
+		}
+		switch {
+		case len(warning) > 1:
+			warning = warning[:1]
+		case showBanner:
+			showBanner = false
+		default:
+			return text
+		}
+	}
+}
+
+// arrange writes the parts of the status that were chosen by content, sizing the boxes to the
+// height left over
+func (r tuiRenderer) arrange(snap Snapshot, warning []string, showBanner bool, width, height int) string {
+	var output strings.Builder
+	for _, line := range warning {
+		output.WriteString(line)
+		output.WriteString("\n")
+	}
+	height -= len(warning)
+	var logBox string
+	if snap.Watch && snap.ShowLog {
+		// The session log takes up to a third of the screen below everything else
+		logLines := r.logLines(snap.Log, max(width-boxFrameWidth, minContentWidth), height/3-boxFrameHeight)
+		logBox = "\n" + box("Session Log", logLines)
+		height -= len(logLines) + boxFrameHeight
+	}
+	if showBanner {
+		output.WriteString(r.banner(snap.Summary, width))
+		output.WriteString("\n")
+		height--
+	}
+	output.WriteString(r.layout(snap, width, height))
+	output.WriteString(logBox)
+	return output.String()
+}
+
+// staleWarning explains that the status shown is from the last successful poll, with the error
+// from the latest one in gray below, kept to a couple of lines
+func (r tuiRenderer) staleWarning(snap Snapshot, width int) []string {
+	warning := r.options.theme.symbol(status.COMPONENT_DEGREDADED_PERFORMANCE) + "Stale data from " + r.options.times.clock(snap.LastUpdate)
+	if snap.Watch {
+		warning += ", retrying"
+	}
+	lines := strings.Split(wrapText(warning, width), "\n")
+	for i, line := range lines {
+		lines[i] = r.options.theme.style(status.COMPONENT_DEGREDADED_PERFORMANCE, pterm.Bold.Sprint(line))
+	}
+	details := strings.Split(wrapText(snap.Err.Error(), width), "\n")
+	for i, line := range details {
+		details[i] = pterm.Gray(line)
+	}
+	return append(lines, truncateLines(details, maxStaleErrorLines)...)
+}
+
+// footer shows when the next poll is due, the response to the last one and the keys that can
+// be pressed in watch mode
+func (r tuiRenderer) footer(snap Snapshot, width int) string {
+	var next string
+	switch {
+	case snap.Paused:
+		next = fmt.Sprintf("Paused, polling every %s once resumed", shortDuration(snap.Interval))
+	case snap.NextPoll.IsZero():
+		next = "Polling…"
+	default:
+		wait := max(snap.NextPoll.Sub(r.options.times.current()).Round(time.Second), 0)
+		next = fmt.Sprintf("Next poll in %s, every %s", wait, shortDuration(snap.Interval))
+	}
+
+	var last string
+	switch {
+	case snap.Err != nil:
+		last = "last poll failed"
+		if snap.StatusCode != 0 {
+			last += " with " + response(snap.StatusCode, snap.Latency)
+		}
+		if snap.ErrorCount > 1 {
+			last += fmt.Sprintf(", %d failures in a row", snap.ErrorCount)
+		}
+	case snap.Summary == nil:
+		last = "waiting for the first poll"
+	case snap.StatusCode != 0:
+		last = response(snap.StatusCode, snap.Latency)
+	case snap.NotModified:
+		last = "last poll found no changes"
+	default:
+		last = "last poll found updates"
+	}
+
+	keys := "r refresh · p pause · +/- poll more or less often · l log · q quit"
+	lines := strings.Split(wrapText(next+" · "+last+"\n"+keys, width), "\n")
+	for i, line := range lines {
+		lines[i] = pterm.Gray(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// response describes an HTTP response and how long it took, such as "304 Not Modified in 85ms"
+func response(code int, latency time.Duration) string {
+	text := strconv.Itoa(code)
+	if name := http.StatusText(code); name != "" {
+		text += " " + name
+	}
+	if latency > 0 {
+		text += " in " + max(latency.Round(time.Millisecond), time.Millisecond).String()
+	}
+	return text
+}
+
+// banner shows the overall status of the page, centered above the boxes
+func (r tuiRenderer) banner(summary *status.SystemStatus, width int) string {
+	overall := summary.Overall()
+	componentStatus := indicatorStatus(overall.Indicator)
+	text := r.options.theme.symbol(componentStatus) + overall.Description
+	indent := max(0, (width-displayWidth(text))/2)
+	return strings.Repeat(" ", indent) + r.options.theme.style(componentStatus, pterm.Bold.Sprint(text))
+}
+
+// layout arranges the status and incident boxes to fit in width and height, placing them side
+// by side on wide terminals and stacking them otherwise
+func (r tuiRenderer) layout(snap Snapshot, width, height int) string {
+	incidents := snap.Summary.Incidents
+	if len(incidents) == 0 {
+		contentWidth := max(width-boxFrameWidth, minContentWidth)
+		return box("System Status", r.componentLines(snap, contentWidth, height-boxFrameHeight))
+	}
+
+	url := incidentURL(incidents[0].ID)
+	if width >= sideBySideWidth {
+		contentWidth := (width-columnGap)/2 - boxFrameWidth
+		componentLines := r.componentLines(snap, contentWidth, height-boxFrameHeight)
+		// The incident URL heads the incident updates so the tops of both boxes line up
+		incidentLines := r.incidentLines(incidents[0], snap.Changes, contentWidth, height-boxFrameHeight-1)
+		incidentLines = append([]string{padLineToWidth(wrapText(url, contentWidth), contentWidth)}, incidentLines...)
+		// Line up the bottoms of both boxes
+		componentLines = padLines(componentLines, len(incidentLines))
+		incidentLines = padLines(incidentLines, len(componentLines))
+		return joinColumns(box("System Status", componentLines), box("Incident Updates", incidentLines), columnGap)
+	}
+
+	contentWidth := max(width-boxFrameWidth, minContentWidth)
+	// Components can use all the height the incident updates don't, but a few lines of updates
+	// are always kept
+	url = wrapText(url, width)
+	urlHeight := strings.Count(url, "\n") + 1
+	componentLines := r.componentLines(snap, contentWidth, height-2*boxFrameHeight-urlHeight-minIncidentLines)
+	incidentLines := r.incidentLines(incidents[0], snap.Changes, contentWidth, height-len(componentLines)-2*boxFrameHeight-urlHeight)
+	return box("System Status", componentLines) + "\n" + url + "\n" + box("Incident Updates", incidentLines)
+}
+
+// componentLines lists the status of every component in at most maxLines lines, followed by a
+// strip showing their history when there is room, and flowing them into columns when there is
+// room for more than one. Components whose status changed recently are shown in inverse video.
+func (r tuiRenderer) componentLines(snap Snapshot, contentWidth, maxLines int) []string {
+	components := visibleComponents(snap.Summary)
+	history := snap.History
+	texts := make([]string, len(components))
+	itemWidth := 0
+	for i, component := range components {
+		texts[i] = fmt.Sprintf("%s%s - %s", r.options.theme.symbol(component.Status), component.Component, statusLabel(component.Status))
+		itemWidth = max(itemWidth, displayWidth(texts[i]))
+	}
+
+	// Strips are shortened to fit next to the widest component, and left out when there is
+	// barely any room for them or when running once, as a single poll has no history to show
+	polls := 0
+	if snap.Watch {
+		polls = history.length()
+	}
+	stripWidth := min(polls, contentWidth-itemWidth-1)
+	if stripWidth < minStripWidth && stripWidth < polls {
+		stripWidth = 0
+	}
+
+	var lines []string
+	if stripWidth > 0 || itemWidth*2+columnGap <= contentWidth {
+		items := make([]string, len(components))
+		for i, component := range components {
+			items[i] = r.componentStyle(snap, component, texts[i])
+			if stripWidth > 0 {
+				items[i] = padLineToWidth(items[i], itemWidth) + " " + history.sparkline(component.ID, stripWidth, r.options.theme)
+			}
+		}
+		if stripWidth > 0 {
+			itemWidth += 1 + stripWidth
+		}
+		lines = flowColumns(items, itemWidth, contentWidth)
+	} else {
+		// A single column, wrapping components with names too long for the terminal
+		for i, component := range components {
+			for _, line := range strings.Split(wrapText(texts[i], contentWidth), "\n") {
+				lines = append(lines, r.componentStyle(snap, component, line))
+			}
+		}
+	}
+
+	lines = truncateLines(lines, maxLines)
+	for i, line := range lines {
+		// Pad line to ensure consistent width across all lines
+		lines[i] = padLineToWidth(line, contentWidth)
+	}
+	return lines
+}
+
+// componentStyle colors text describing a component by its status, in inverse video if the
+// status changed recently
+func (r tuiRenderer) componentStyle(snap Snapshot, component status.Components, text string) string {
+	if snap.Changes.component(component.ID) {
+		text = highlightStyle.Sprint(text)
+	}
+	return r.options.theme.style(component.Status, text)
+}
+
+// incidentLines lists the updates to incident, newest first, in at most maxLines lines. Recent
+// updates are marked with a badge.
+func (r tuiRenderer) incidentLines(incident status.Incidents, changes highlights, contentWidth, maxLines int) []string {
+	var lines []string
+	for _, update := range incident.IncidentUpdates {
+		var badge string
+		if changes.update(incident, update) {
+			badge = highlightStyle.Sprint(" NEW ") + " "
+		}
+		wrappedText := wrapText(fmt.Sprintf("%sUpdated %s - %s", badge, r.options.times.statusTime(update.Timestamp), renderMarkup(update.Update, terminalMarkup)), contentWidth)
+		for _, line := range strings.Split(wrappedText, "\n") {
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	lines = truncateLines(lines, maxLines)
+	for i, line := range lines {
+		lines[i] = padLineToWidth(line, contentWidth)
+	}
+	return lines
+}
+
+// logLines lists the most recent entries of the session log in at most maxLines lines, newest last
+func (r tuiRenderer) logLines(log eventLog, contentWidth, maxLines int) []string {
+	var lines []string
+	for _, entry := range log {
+		wrappedText := wrapText(fmt.Sprintf("%s %s", r.options.times.clock(entry.Time), entry.Message), contentWidth)
+		lines = append(lines, strings.Split(wrappedText, "\n")...)
+	}
+	if len(lines) == 0 {
+		lines = []string{pterm.Gray("No changes yet")}
+	}
+
+	maxLines = max(maxLines, 1)
+	if len(lines) > maxLines {
+		hidden := len(lines) - maxLines + 1
+		lines = append([]string{pterm.Gray(fmt.Sprintf("… %d earlier lines", hidden))}, lines[len(lines)-maxLines+1:]...)
+	}
+	for i, line := range lines {
+		lines[i] = padLineToWidth(line, contentWidth)
+	}
+	return lines
+}
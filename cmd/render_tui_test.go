@@ -0,0 +1,733 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// renderTUI renders with the fullscreen renderer on a standard 80x24 terminal
+func renderTUI(summary *status.SystemStatus, err error, lastUpdate time.Time, watch bool) string {
+	snap := Snapshot{
+		Summary:    summary,
+		Err:        err,
+		LastUpdate: lastUpdate,
+		Watch:      watch,
+	}
+	return tuiRenderer{options: testRenderOptions()}.Render(snap, 80, 24)
+}
+
+func TestTuiRenderer_NilSummary(t *testing.T) {
+	// Test rendering with nil summary
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	result := renderTUI(nil, nil, lastUpdate, false)
+
+	// Should contain last updated time
+	if !strings.Contains(result, "Last Updated") {
+		t.Error("Expected output to contain 'Last Updated'")
+	}
+
+	// Should not be empty
+	if result == "" {
+		t.Error("Expected non-empty output for nil summary")
+	}
+}
+
+func TestTuiRenderer_WithError(t *testing.T) {
+	// Test rendering with error state
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	errMsg := "Failed to fetch status"
+	result := renderTUI(nil, errors.New(errMsg), lastUpdate, false)
+
+	// Should contain error message
+	if !strings.Contains(result, errMsg) {
+		t.Errorf("Expected output to contain error message %q", errMsg)
+	}
+
+	// Should contain last updated time
+	if !strings.Contains(result, "Last Updated") {
+		t.Error("Expected output to contain 'Last Updated'")
+	}
+}
+
+func TestTuiRenderer_KeepsStaleDataOnError(t *testing.T) {
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summary := &status.SystemStatus{
+		Components: manyComponents(30),
+		Incidents:  []status.Incidents{activeIncident("We are investigating reports of degraded performance.")},
+	}
+	err := errors.New(strings.Repeat("dial tcp: connection refused ", 10))
+	result := renderTUI(summary, err, lastUpdate, true)
+
+	assertFits(t, result, 80, 24)
+	plain := stripAnsiCodes(result)
+	if !strings.Contains(plain, "Stale data from 2:30 PM, retrying") {
+		t.Error("expected a warning that the data is stale")
+	}
+	if !strings.Contains(plain, "dial tcp: connection refused") {
+		t.Error("expected the error details to be shown")
+	}
+	if strings.Contains(plain, "Error retrieving current GitHub status") {
+		t.Error("expected the full error message not to replace the status")
+	}
+	if lineContaining(result, "Service 00") < 0 || lineContaining(result, "Incident Updates") < 0 {
+		t.Error("expected the last known components and incidents to still be shown")
+	}
+	if lineContaining(result, "Stale data") > lineContaining(result, "Service 00") {
+		t.Error("expected the warning above the components")
+	}
+}
+
+func TestTuiRenderer_WithComponents(t *testing.T) {
+	// Create test data with components
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summary := &status.SystemStatus{
+		Components: []status.Components{
+			{
+				ID:        "comp1",
+				Component: "Git Operations",
+				Status:    status.COMPONENT_OPERATIONAL,
+			},
+			{
+				ID:        "comp2",
+				Component: "API Requests",
+				Status:    status.COMPONENT_DEGREDADED_PERFORMANCE,
+			},
+		},
+		Incidents: []status.Incidents{},
+	}
+
+	result := renderTUI(summary, nil, lastUpdate, false)
+
+	// Should contain component names
+	if !strings.Contains(result, "Git Operations") {
+		t.Error("Expected output to contain 'Git Operations'")
+	}
+	if !strings.Contains(result, "API Requests") {
+		t.Error("Expected output to contain 'API Requests'")
+	}
+
+	// Should contain status information
+	if !strings.Contains(result, "Operational") {
+		t.Error("Expected output to contain 'Operational'")
+	}
+	if !strings.Contains(result, "Degraded Performance") {
+		t.Error("Expected output to contain 'Degraded Performance'")
+	}
+
+	// Should contain System Status box title
+	if !strings.Contains(result, "System Status") {
+		t.Error("Expected output to contain 'System Status' box title")
+	}
+}
+
+func TestTuiRenderer_FilterIgnoredComponent(t *testing.T) {
+	// Test that ignored component is filtered out
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summary := &status.SystemStatus{
+		Components: []status.Components{
+			{
+				ID:        IGNORE_GHSTATUS_COMPONENTID,
+				Component: "Visit www.githubstatus.com",
+				Status:    status.COMPONENT_OPERATIONAL,
+			},
+			{
+				ID:        "comp1",
+				Component: "Git Operations",
+				Status:    status.COMPONENT_OPERATIONAL,
+			},
+		},
+		Incidents: []status.Incidents{},
+	}
+
+	result := renderTUI(summary, nil, lastUpdate, false)
+
+	// Should NOT contain ignored component
+	if strings.Contains(result, "Visit www.githubstatus.com") {
+		t.Error("Expected output to NOT contain ignored component")
+	}
+
+	// Should contain the other component
+	if !strings.Contains(result, "Git Operations") {
+		t.Error("Expected output to contain 'Git Operations'")
+	}
+}
+
+func TestTuiRenderer_AllComponentStatuses(t *testing.T) {
+	// Test all different component status types
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summary := &status.SystemStatus{
+		Components: []status.Components{
+			{
+				ID:        "comp1",
+				Component: "Service A",
+				Status:    status.COMPONENT_OPERATIONAL,
+			},
+			{
+				ID:        "comp2",
+				Component: "Service B",
+				Status:    status.COMPONENT_DEGREDADED_PERFORMANCE,
+			},
+			{
+				ID:        "comp3",
+				Component: "Service C",
+				Status:    status.COMPONENT_PARTIAL_OUTAGE,
+			},
+			{
+				ID:        "comp4",
+				Component: "Service D",
+				Status:    status.COMPONENT_MAJOR_OUTAGE,
+			},
+		},
+		Incidents: []status.Incidents{},
+	}
+
+	result := renderTUI(summary, nil, lastUpdate, false)
+
+	// Check all status types are rendered
+	statusChecks := map[string]string{
+		"Service A": "Operational",
+		"Service B": "Degraded Performance",
+		"Service C": "Partial Outage",
+		"Service D": "Major Outage",
+	}
+
+	for service, statusText := range statusChecks {
+		if !strings.Contains(result, service) {
+			t.Errorf("Expected output to contain %q", service)
+		}
+		if !strings.Contains(result, statusText) {
+			t.Errorf("Expected output to contain status %q", statusText)
+		}
+	}
+}
+
+func TestTuiRenderer_WithIncidents(t *testing.T) {
+	// Test rendering with incidents
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	incidentTime := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	summary := &status.SystemStatus{
+		Components: []status.Components{
+			{
+				ID:        "comp1",
+				Component: "Git Operations",
+				Status:    status.COMPONENT_OPERATIONAL,
+			},
+		},
+		Incidents: []status.Incidents{
+			{
+				ID:     "incident123",
+				Status: "investigating",
+				IncidentUpdates: []status.IncidentUpdate{
+					{
+						Status: "investigating",
+						Update: "We are investigating degraded performance",
+						Timestamp: &status.Time{
+							Time: &incidentTime,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := renderTUI(summary, nil, lastUpdate, false)
+
+	// Should contain incident URL
+	if !strings.Contains(result, "githubstatus.com/incidents/incident123") {
+		t.Error("Expected output to contain incident URL")
+	}
+
+	// Should contain incident update text
+	if !strings.Contains(result, "investigating degraded performance") {
+		t.Error("Expected output to contain incident update text")
+	}
+
+	// Should contain Incident Updates box title
+	if !strings.Contains(result, "Incident Updates") {
+		t.Error("Expected output to contain 'Incident Updates' box title")
+	}
+
+	// Should contain both System Status and Incident Updates boxes
+	if !strings.Contains(result, "System Status") {
+		t.Error("Expected output to contain 'System Status' box title")
+	}
+}
+
+func TestTuiRenderer_EmptyComponents(t *testing.T) {
+	// Test with empty components list
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summary := &status.SystemStatus{
+		Components: []status.Components{},
+		Incidents:  []status.Incidents{},
+	}
+
+	result := renderTUI(summary, nil, lastUpdate, false)
+
+	// Should not crash and should contain basic elements
+	if !strings.Contains(result, "Last Updated") {
+		t.Error("Expected output to contain 'Last Updated'")
+	}
+
+	// Should contain System Status box even if empty
+	if !strings.Contains(result, "System Status") {
+		t.Error("Expected output to contain 'System Status' box title")
+	}
+}
+
+func TestTuiRenderer_ContainsNewlines(t *testing.T) {
+	// Test that output contains newlines for padding
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summary := &status.SystemStatus{
+		Components: []status.Components{
+			{
+				ID:        "comp1",
+				Component: "Test Component",
+				Status:    status.COMPONENT_OPERATIONAL,
+			},
+		},
+		Incidents: []status.Incidents{},
+	}
+
+	result := renderTUI(summary, nil, lastUpdate, false)
+
+	// Should contain multiple newlines for terminal height padding
+	newlineCount := strings.Count(result, "\n")
+	if newlineCount < 5 {
+		t.Errorf("Expected at least 5 newlines for padding, got %d", newlineCount)
+	}
+}
+
+func TestTuiRenderer_ConsistentOutput(t *testing.T) {
+	// Test that rendering twice with same inputs gives consistent results
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summary := &status.SystemStatus{
+		Components: []status.Components{
+			{
+				ID:        "comp1",
+				Component: "Test",
+				Status:    status.COMPONENT_OPERATIONAL,
+			},
+		},
+		Incidents: []status.Incidents{},
+	}
+
+	result1 := renderTUI(summary, nil, lastUpdate, false)
+	result2 := renderTUI(summary, nil, lastUpdate, false)
+
+	if result1 != result2 {
+		t.Error("tuiRenderer should produce consistent output for same inputs")
+	}
+}
+
+func TestTuiRenderer_WatchModeHelpText(t *testing.T) {
+	// Test that watch mode includes help text at bottom
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	summary := &status.SystemStatus{
+		Components: []status.Components{
+			{
+				ID:        "comp1",
+				Component: "Test Component",
+				Status:    status.COMPONENT_OPERATIONAL,
+			},
+		},
+		Incidents: []status.Incidents{},
+	}
+
+	// Test without watch mode
+	resultNoWatch := renderTUI(summary, nil, lastUpdate, false)
+	if strings.Contains(resultNoWatch, "r refresh") {
+		t.Error("Expected no help text when watch mode is disabled")
+	}
+
+	// Test with watch mode
+	resultWatch := renderTUI(summary, nil, lastUpdate, true)
+	if !strings.Contains(resultWatch, "r refresh") {
+		t.Error("Expected help text to contain 'r refresh' in watch mode")
+	}
+	if !strings.Contains(resultWatch, "quit") {
+		t.Error("Expected help text to mention quit option in watch mode")
+	}
+}
+
+func TestTuiRenderer_FallsBackToDefaultSize(t *testing.T) {
+	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	snap := Snapshot{LastUpdate: lastUpdate}
+
+	result := tuiRenderer{}.Render(snap, 0, 0)
+	expected := tuiRenderer{}.Render(snap, 80, 24)
+	if result != expected {
+		t.Error("expected an unknown terminal size to render as 80x24")
+	}
+}
+
+// manyComponents returns n operational components with short names
+func manyComponents(n int) []status.Components {
+	components := make([]status.Components, n)
+	for i := range components {
+		components[i] = status.Components{
+			ID:        fmt.Sprintf("comp%d", i),
+			Component: fmt.Sprintf("Service %02d", i),
+			Status:    status.COMPONENT_OPERATIONAL,
+		}
+	}
+	return components
+}
+
+// activeIncident returns an incident with a single update
+func activeIncident(update string) status.Incidents {
+	incidentTime := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	return status.Incidents{
+		ID:     "incident123",
+		Status: status.INCIDENT_INVESTIGATING,
+		IncidentUpdates: []status.IncidentUpdate{
+			{
+				Status:    status.INCIDENT_INVESTIGATING,
+				Update:    update,
+				Timestamp: &status.Time{Time: &incidentTime},
+			},
+		},
+	}
+}
+
+// assertFits fails the test when output takes up more than width columns or height lines
+func assertFits(t *testing.T, output string, width, height int) {
+	t.Helper()
+	lines := strings.Split(output, "\n")
+	if len(lines) > height {
+		t.Errorf("expected at most %d lines, got %d", height, len(lines))
+	}
+	for _, line := range lines {
+		if displayWidth(line) > width {
+			t.Errorf("expected lines at most %d cells wide, got %d: %q", width, displayWidth(line), stripAnsiCodes(line))
+		}
+	}
+}
+
+// lineContaining returns the index of the first line of output containing text, or -1
+func lineContaining(output, text string) int {
+	for i, line := range strings.Split(stripAnsiCodes(output), "\n") {
+		if strings.Contains(line, text) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestTuiRenderer_SideBySideOnWideTerminals(t *testing.T) {
+	snap := Snapshot{
+		Summary: &status.SystemStatus{
+			Components: manyComponents(3),
+			Incidents:  []status.Incidents{activeIncident("We are investigating degraded performance")},
+		},
+	}
+	renderer := tuiRenderer{options: testRenderOptions()}
+
+	wide := renderer.Render(snap, 160, 40)
+	assertFits(t, wide, 160, 40)
+	if lineContaining(wide, "System Status") != lineContaining(wide, "Incident Updates") {
+		t.Error("expected the status and incident boxes to be side by side on a wide terminal")
+	}
+
+	narrow := renderer.Render(snap, 80, 40)
+	assertFits(t, narrow, 80, 40)
+	if lineContaining(narrow, "System Status") >= lineContaining(narrow, "Incident Updates") {
+		t.Error("expected the incident box to be below the status box on a narrow terminal")
+	}
+}
+
+func TestTuiRenderer_FlowsComponentsIntoColumns(t *testing.T) {
+	snap := Snapshot{Summary: &status.SystemStatus{Components: manyComponents(6)}}
+	renderer := tuiRenderer{options: testRenderOptions()}
+
+	result := renderer.Render(snap, 100, 24)
+	assertFits(t, result, 100, 24)
+	// Components run down the first column before the second
+	if lineContaining(result, "Service 00") != lineContaining(result, "Service 02") {
+		t.Error("expected components to be placed in columns on a wide terminal")
+	}
+	if lineContaining(result, "Service 00") == lineContaining(result, "Service 01") {
+		t.Error("expected components to run down each column")
+	}
+
+	result = renderer.Render(snap, 40, 24)
+	assertFits(t, result, 40, 24)
+	if lineContaining(result, "Service 00") == lineContaining(result, "Service 01") {
+		t.Error("expected a single column of components on a narrow terminal")
+	}
+}
+
+func TestTuiRenderer_CollapsesOnSmallTerminals(t *testing.T) {
+	snap := Snapshot{
+		Summary: &status.SystemStatus{
+			Components: []status.Components{
+				{ID: "comp1", Component: "Webhooks and Codespaces and Copilot", Status: status.COMPONENT_PARTIAL_OUTAGE},
+			},
+			Incidents: []status.Incidents{activeIncident("We are investigating reports of degraded performance for Webhooks, Codespaces and Copilot")},
+		},
+	}
+	result := tuiRenderer{options: testRenderOptions()}.Render(snap, 32, 24)
+	assertFits(t, result, 32, 24)
+	if !strings.Contains(result, "Webhooks") || !strings.Contains(result, "Outage") {
+		t.Error("expected long component names to wrap instead of being cut off")
+	}
+
+	snap.Summary.Components = manyComponents(30)
+	result = tuiRenderer{options: testRenderOptions()}.Render(snap, 80, 16)
+	assertFits(t, result, 80, 16)
+	if !strings.Contains(result, "more lines") {
+		t.Error("expected a note about components that don't fit on a short terminal")
+	}
+	if !strings.Contains(result, "Incident Updates") {
+		t.Error("expected incident updates to be kept on a short terminal")
+	}
+
+	// In watch mode the stale warning and footer have to fit as well
+	snap.Watch = true
+	snap.Err = errors.New("connection refused")
+	for _, width := range []int{30, 80} {
+		for height := 8; height <= 12; height++ {
+			result = tuiRenderer{options: testRenderOptions()}.Render(snap, width, height)
+			assertFits(t, result, width, height)
+			footer := lineContaining(result, "Polling")
+			if footer < 1 || strings.TrimSpace(stripAnsiCodes(strings.Split(result, "\n")[footer-1])) != "" {
+				t.Errorf("expected the footer below a blank line at %dx%d, got:\n%s", width, height, stripAnsiCodes(result))
+			}
+		}
+	}
+}
+
+func TestTuiRenderer_OverallStatusBanner(t *testing.T) {
+	snap := Snapshot{
+		Summary: &status.SystemStatus{
+			Status:     status.PageStatus{Indicator: status.INDICATOR_MINOR, Description: "Partially Degraded Service"},
+			Components: manyComponents(2),
+		},
+	}
+	result := tuiRenderer{options: testRenderOptions()}.Render(snap, 80, 24)
+	banner := lineContaining(result, "Partially Degraded Service")
+	if banner == -1 {
+		t.Fatal("expected the overall status description to be shown")
+	}
+	if banner >= lineContaining(result, "System Status") {
+		t.Error("expected the overall status to be shown above the components")
+	}
+
+	// Without an indicator the banner is based on the components
+	snap.Summary.Status = status.PageStatus{}
+	result = tuiRenderer{options: testRenderOptions()}.Render(snap, 80, 24)
+	if !strings.Contains(result, "All Systems Operational") {
+		t.Error("expected the overall status to fall back to the component statuses")
+	}
+}
+
+func TestTuiRenderer_ComponentHistory(t *testing.T) {
+	components := manyComponents(2)
+	history := componentHistory{}
+	for _, componentStatus := range []status.ComponentStatus{status.COMPONENT_OPERATIONAL, status.COMPONENT_MAJOR_OUTAGE, status.COMPONENT_OPERATIONAL} {
+		components[0].Status = componentStatus
+		history.record(&status.SystemStatus{Components: components})
+	}
+	snap := Snapshot{
+		Summary: &status.SystemStatus{Components: components},
+		History: history,
+		Watch:   true,
+	}
+
+	result := stripAnsiCodes(tuiRenderer{options: testRenderOptions()}.Render(snap, 80, 24))
+	if !strings.Contains(result, "Service 00 - Operational ▁█▁") {
+		t.Error("expected a history strip next to the component that changed")
+	}
+	if !strings.Contains(result, "Service 01 - Operational ▁▁▁") {
+		t.Error("expected a history strip next to the component that didn't change")
+	}
+
+	// On a narrow terminal the strip is shortened to the most recent polls
+	for i := 0; i < historyLength; i++ {
+		history.record(&status.SystemStatus{Components: components})
+	}
+	result = tuiRenderer{options: testRenderOptions()}.Render(snap, 50, 24)
+	assertFits(t, result, 50, 24)
+	if !strings.Contains(stripAnsiCodes(result), "Service 00 - Operational ▁▁▁") {
+		t.Error("expected a shortened history strip on a narrow terminal")
+	}
+}
+
+func TestTuiRenderer_NoHistoryWhenRunOnce(t *testing.T) {
+	components := manyComponents(2)
+	history := componentHistory{}
+	history.record(&status.SystemStatus{Components: components})
+	snap := Snapshot{
+		Summary: &status.SystemStatus{Components: components},
+		History: history,
+	}
+
+	result := stripAnsiCodes(tuiRenderer{options: testRenderOptions()}.Render(snap, 80, 24))
+	if strings.Contains(result, "▁") {
+		t.Error("expected no history strips when running once")
+	}
+	if lineContaining(result, "Service 00") != lineContaining(result, "Service 01") {
+		t.Error("expected both components on one line without strips taking up room")
+	}
+}
+
+func TestTuiRenderer_SessionLog(t *testing.T) {
+	start := time.Date(2024, 1, 15, 14, 2, 0, 0, time.UTC)
+	snap := Snapshot{
+		Summary: &status.SystemStatus{Components: manyComponents(2)},
+		Watch:   true,
+	}
+	for i := 0; i < 20; i++ {
+		snap.Log.add(start.Add(time.Duration(i)*time.Minute), fmt.Sprintf("Change %02d", i))
+	}
+	renderer := tuiRenderer{options: testRenderOptions()}
+
+	if strings.Contains(renderer.Render(snap, 80, 24), "Session Log") {
+		t.Error("expected the session log to be hidden until it is toggled")
+	}
+
+	snap.ShowLog = true
+	result := renderer.Render(snap, 80, 24)
+	assertFits(t, result, 80, 24)
+	if lineContaining(result, "Session Log") <= lineContaining(result, "System Status") {
+		t.Error("expected the session log below the component status")
+	}
+	if !strings.Contains(result, "2:21 PM Change 19") {
+		t.Error("expected the newest log entry to be shown")
+	}
+	if strings.Contains(result, "Change 00") || !strings.Contains(result, "earlier lines") {
+		t.Error("expected the oldest entries to be left out when the log doesn't fit")
+	}
+
+	snap.Watch = false
+	if strings.Contains(renderer.Render(snap, 80, 24), "Session Log") {
+		t.Error("expected no session log when not watching")
+	}
+}
+
+func TestTuiRenderer_HighlightsChanges(t *testing.T) {
+	incident := activeIncident("We are investigating degraded performance")
+	incident.IncidentUpdates = append([]status.IncidentUpdate{{ID: "update2", Status: status.INCIDENT_IDENTIFIED, Update: "We have identified the cause"}}, incident.IncidentUpdates...)
+	summary := &status.SystemStatus{Components: manyComponents(2), Incidents: []status.Incidents{incident}}
+	changes := newHighlights()
+	changes.components["comp1"] = highlightPolls
+	changes.updates["update2"] = highlightPolls
+
+	// The monochrome theme leaves operational components unstyled, so only the highlight is applied
+	options, err := newRenderOptions("monochrome", "none", "UTC", defaultTimeFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := tuiRenderer{options: options}.Render(Snapshot{Summary: summary, Changes: changes}, 80, 24)
+	plain := stripAnsiCodes(result)
+	if !strings.Contains(plain, " NEW  Updated") {
+		t.Error("expected the new incident update to have a badge")
+	}
+	if strings.Count(plain, " NEW ") != 1 {
+		t.Error("expected only the new incident update to have a badge")
+	}
+	// The box restyles each line, so look for the escape sequence that starts the highlight
+	start := highlightStyle.Sprint("x")
+	start = start[:strings.Index(start, "x")]
+	if !strings.Contains(result, start+"Service 01 - Operational") {
+		t.Error("expected the changed component to be highlighted")
+	}
+	if strings.Contains(result, start+"Service 00 - Operational") {
+		t.Error("expected the unchanged component not to be highlighted")
+	}
+}
+
+func TestTuiRenderer_Footer(t *testing.T) {
+	now := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	options := testRenderOptions()
+	options.times.now = func() time.Time { return now }
+	renderer := tuiRenderer{options: options}
+	snap := Snapshot{
+		Summary:  &status.SystemStatus{Components: manyComponents(2)},
+		Watch:    true,
+		Interval: time.Minute,
+		NextPoll: now.Add(42 * time.Second),
+	}
+
+	tests := []struct {
+		name     string
+		update   func(snap *Snapshot)
+		expected []string
+	}{
+		{
+			name:     "counting down",
+			update:   func(snap *Snapshot) {},
+			expected: []string{"Next poll in 42s, every 1m", "last poll found updates"},
+		},
+		{
+			name:     "nothing changed",
+			update:   func(snap *Snapshot) { snap.NotModified = true },
+			expected: []string{"last poll found no changes"},
+		},
+		{
+			name: "ok response",
+			update: func(snap *Snapshot) {
+				snap.StatusCode = http.StatusOK
+				snap.Latency = 123456 * time.Microsecond
+			},
+			expected: []string{"200 OK in 123ms"},
+		},
+		{
+			name: "not modified response",
+			update: func(snap *Snapshot) {
+				snap.NotModified = true
+				snap.StatusCode = http.StatusNotModified
+				snap.Latency = 85 * time.Millisecond
+			},
+			expected: []string{"304 Not Modified in 85ms"},
+		},
+		{
+			name:     "poll failed",
+			update:   func(snap *Snapshot) { snap.Err = errors.New("connection refused"); snap.ErrorCount = 1 },
+			expected: []string{"last poll failed"},
+		},
+		{
+			name: "polls keep failing",
+			update: func(snap *Snapshot) {
+				snap.Err = errors.New("unexpected http status code")
+				snap.StatusCode = http.StatusServiceUnavailable
+				snap.Latency = 40 * time.Millisecond
+				snap.ErrorCount = 3
+			},
+			expected: []string{"last poll failed with 503 Service Unavailable in 40ms, 3 failures in a row"},
+		},
+		{
+			name:     "paused",
+			update:   func(snap *Snapshot) { snap.Paused = true; snap.NextPoll = time.Time{} },
+			expected: []string{"Paused, polling every 1m once resumed"},
+		},
+		{
+			name:     "poll overdue",
+			update:   func(snap *Snapshot) { snap.NextPoll = now.Add(-time.Second) },
+			expected: []string{"Next poll in 0s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := snap
+			tt.update(&snap)
+			result := renderer.Render(snap, 80, 24)
+			assertFits(t, result, 80, 24)
+			plain := strings.Join(strings.Fields(stripAnsiCodes(result)), " ")
+			for _, expected := range tt.expected {
+				if !strings.Contains(plain, expected) {
+					t.Errorf("expected the footer to contain %q", expected)
+				}
+			}
+			if !strings.Contains(plain, "p pause") {
+				t.Error("expected the footer to explain how to pause")
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -11,7 +12,7 @@ import (
 	"time"
 	"unsafe"
 
-	"github.com/mitchellh/go-wordwrap"
+	"github.com/mattn/go-runewidth"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/wwsean08/gh-gh-status/status"
@@ -48,8 +49,9 @@ func setNonCanonicalMode(fd int) (*term.State, error) {
 }
 
 // handleKeyboardInput listens for keyboard input and sends signals to appropriate channels
-// Note: Terminal must already be in non-canonical mode before calling this function
-func handleKeyboardInput(refreshChan chan bool, done chan bool) {
+// Note: Terminal must already be in non-canonical mode before calling this function.
+// Keys whose channel is nil are ignored.
+func handleKeyboardInput(refreshChan chan bool, toggleLogChan chan bool, pauseChan chan bool, intervalChan chan int, done chan bool) {
 	buf := make([]byte, 1)
 	for {
 		n, err := os.Stdin.Read(buf)
@@ -65,6 +67,24 @@ func handleKeyboardInput(refreshChan chan bool, done chan bool) {
 			default:
 				// Channel full, skip
 			}
+		case 'l', 'L':
+			// Show or hide the session log
+			select {
+			case toggleLogChan <- true:
+			default:
+			}
+		case 'p', 'P':
+			// Pause or resume polling
+			select {
+			case pauseChan <- true:
+			default:
+			}
+		case '+', '=', '-', '_':
+			// Poll more or less often, '=' and '_' save reaching for shift
+			select {
+			case intervalChan <- intervalKeyStep(buf[0]):
+			default:
+			}
 		case 'q', 'Q', 3: // 3 is Ctrl+C
 			// Quit requested
 			select {
@@ -76,16 +96,24 @@ func handleKeyboardInput(refreshChan chan bool, done chan bool) {
 	}
 }
 
-var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+// ansiRegex matches escape sequences, which take no space on screen: CSI sequences such as
+// SGR color codes, OSC sequences such as hyperlinks, and the remaining two byte escapes
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x1b\x07]*(?:\x1b\\|\x07)|\x1b[@-Z\\-_]`)
 
-// stripAnsiCodes removes ANSI color codes to get actual string length
+// stripAnsiCodes removes ANSI escape sequences to get the text shown on screen
 func stripAnsiCodes(s string) string {
 	return ansiRegex.ReplaceAllString(s, "")
 }
 
+// displayWidth is the number of terminal cells s takes up, wide characters such as CJK and
+// emoji take two cells and combining marks none
+func displayWidth(s string) int {
+	return runewidth.StringWidth(stripAnsiCodes(s))
+}
+
 // padLineToWidth pads a line (which may contain ANSI codes) to the specified width
 func padLineToWidth(line string, width int) string {
-	actualLength := len(stripAnsiCodes(line))
+	actualLength := displayWidth(line)
 	if actualLength >= width {
 		return line
 	}
@@ -94,14 +122,19 @@ func padLineToWidth(line string, width int) string {
 
 // eventLoopParams contains all the parameters needed for the event loop
 type eventLoopParams struct {
-	client       *status.Client
-	area         *pterm.AreaPrinter
+	ctx          context.Context
+	watcher      *status.Watcher
+	events       <-chan status.Event
+	renderer     Renderer
+	display      display
 	watch        bool
 	sigChan      chan os.Signal
-	pollChan     chan bool
 	resizeChan   chan bool
 	refreshChan  chan bool
-	ticker       *time.Ticker
+	toggleLog    chan bool
+	pause        chan bool
+	interval     chan int
+	countdown    <-chan time.Time
 	done         chan bool
 	currentState *eventLoopState
 }
@@ -109,15 +142,49 @@ type eventLoopParams struct {
 // eventLoopState holds the mutable state for the event loop
 type eventLoopState struct {
 	currentSummary *status.SystemStatus
-	outputError    bool
-	errMsg         string
+	lastErr        error
+	notModified    bool
+	statusCode     int
+	latency        time.Duration
+	errorCount     int
 	lastUpdate     time.Time
+	history        componentHistory
+	log            eventLog
+	showLog        bool
+	changes        highlights
+}
+
+// render draws the current state with the selected renderer
+func render(params eventLoopParams) {
+	width, height := terminalSize()
+	snap := Snapshot{
+		Summary:     params.currentState.currentSummary,
+		Err:         params.currentState.lastErr,
+		LastUpdate:  params.currentState.lastUpdate,
+		Watch:       params.watch,
+		History:     params.currentState.history,
+		Log:         params.currentState.log,
+		ShowLog:     params.currentState.showLog,
+		Changes:     params.currentState.changes,
+		NotModified: params.currentState.notModified,
+		StatusCode:  params.currentState.statusCode,
+		Latency:     params.currentState.latency,
+		ErrorCount:  params.currentState.errorCount,
+	}
+	if params.watcher != nil {
+		snap.Paused = params.watcher.Paused()
+		snap.Interval = params.watcher.Interval()
+		snap.NextPoll, _ = params.watcher.NextPoll()
+	}
+	params.display.Update(params.renderer.Render(snap, width, height))
 }
 
 // runEventLoop executes the main event loop for handling terminal resize, polling, and rendering
 func runEventLoop(params eventLoopParams) {
 	for {
 		select {
+		case <-params.ctx.Done():
+			return
 		case <-params.sigChan:
 			// Terminal was resized, trigger immediate re-render with current data
 			select {
@@ -125,35 +192,63 @@ func runEventLoop(params eventLoopParams) {
 			default:
 				// Channel full, skip this resize event
 			}
-		case <-params.ticker.C:
+		case <-params.refreshChan:
+			// Manual refresh requested (in watch mode only), replacing any poll still in flight
 			if params.watch {
-				// Time to poll for new data
-				params.pollChan <- true
+				params.watcher.Refresh()
 			}
-		case <-params.refreshChan:
-			// Manual refresh requested (in watch mode only)
+		case <-params.toggleLog:
+			params.currentState.showLog = !params.currentState.showLog
+			render(params)
+		case <-params.pause:
 			if params.watch {
-				params.pollChan <- true
+				if params.watcher.Paused() {
+					params.watcher.Resume()
+				} else {
+					params.watcher.Pause()
+				}
+				render(params)
 			}
-		case <-params.pollChan:
-			// Poll for new data
-			summary, err := params.client.Poll()
-			if err != nil {
-				params.currentState.errMsg = fmt.Sprintf("Error retrieving current GitHub status, if this is in watch mode, it will try again in 1 minute.\nError Message: %s", err.Error())
-				params.currentState.outputError = true
+		case step := <-params.interval:
+			if params.watch {
+				params.watcher.SetInterval(nextInterval(params.watcher.Interval(), step))
+				render(params)
+			}
+		case <-params.countdown:
+			// Keep the time until the next poll up to date
+			render(params)
+		case event, ok := <-params.events:
+			if !ok {
+				// The watcher has stopped
+				return
+			}
+			params.currentState.log.record(event, params.currentState.currentSummary, params.currentState.lastErr)
+			if event.Err == nil {
+				params.currentState.changes.record(params.currentState.currentSummary, event.Status)
+			}
+			params.currentState.lastErr = event.Err
+			params.currentState.notModified = event.NotModified
+			params.currentState.statusCode = event.StatusCode
+			params.currentState.latency = event.Latency
+			if event.Err != nil {
+				params.currentState.errorCount++
 			} else {
-				params.currentState.errMsg = ""
-				params.currentState.outputError = false
+				params.currentState.errorCount = 0
+			}
+			if event.Err == nil {
 				// Update last check time even if there's no new data (304 response)
-				params.currentState.lastUpdate = time.Now()
+				params.currentState.lastUpdate = event.Time
+			}
+			// The watcher always hands back the last known summary on success, including 304 responses
+			if event.Status != nil {
+				params.currentState.currentSummary = event.Status
 			}
-			if summary != nil {
-				params.currentState.currentSummary = summary
+			if event.Err == nil && event.Status != nil {
+				params.currentState.history.record(event.Status)
 			}
 
 			// Render UI with current data
-			output := renderUI(params.currentState.currentSummary, params.currentState.outputError, params.currentState.errMsg, params.currentState.lastUpdate, params.watch)
-			params.area.Update(output)
+			render(params)
 
 			if !params.watch {
 				params.done <- true
@@ -161,127 +256,13 @@ func runEventLoop(params eventLoopParams) {
 			}
 		case <-params.resizeChan:
 			// Clear the area to prevent artifacts from previous render
-			params.area.Clear()
+			params.display.Clear()
 			// Re-render with existing data (no API poll needed)
-			output := renderUI(params.currentState.currentSummary, params.currentState.outputError, params.currentState.errMsg, params.currentState.lastUpdate, params.watch)
-			params.area.Update(output)
+			render(params)
 		}
 	}
 }
 
-// renderUI generates the UI output based on current data and terminal dimensions
-func renderUI(summary *status.SystemStatus, outputError bool, errMsg string, lastUpdate time.Time, watch bool) string {
-	// Get terminal dimensions
-	termWidth, termHeight, err := term.GetSize(int(os.Stdout.Fd()))
-	if err != nil {
-		// Fallback to default values if terminal size can't be determined
-		termWidth = 80
-		termHeight = 24
-	}
-
-	// Calculate available width for content (accounting for box borders and padding)
-	contentWidth := termWidth - 6
-	if contentWidth < 40 {
-		contentWidth = 40 // Minimum width
-	}
-
-	updateTime := pterm.DefaultBasicText.Sprintf("Last Updated %s \n", lastUpdate.Format("3:04 PM"))
-
-	var outputComponentsBox string
-	var outputIncidentsBox string
-	var incidentURL string
-	var outputIncidents bool
-
-	if summary != nil {
-		components := summary.Components
-		componentSB := strings.Builder{}
-
-		// Build component status list with proper width
-		for _, component := range components {
-			if component.ID == IGNORE_GHSTATUS_COMPONENTID {
-				continue
-			}
-			statusText := ""
-			if component.Status == status.COMPONENT_OPERATIONAL {
-				statusText = pterm.Green(pterm.Sprintf("%s - Operational", component.Component))
-			} else if component.Status == status.COMPONENT_DEGREDADED_PERFORMANCE {
-				statusText = pterm.LightYellow(pterm.Sprintf("%s - Degraded Performance", component.Component))
-			} else if component.Status == status.COMPONENT_PARTIAL_OUTAGE {
-				statusText = pterm.Yellow(pterm.Sprintf("%s - Partial Outage", component.Component))
-			} else if component.Status == status.COMPONENT_MAJOR_OUTAGE {
-				statusText = pterm.Red(pterm.Sprintf("%s - Major Outage", component.Component))
-			} else {
-				statusText = pterm.Sprintf("%s - %s", component.Component, component.Status)
-			}
-			// Pad line to ensure consistent width across all lines
-			paddedLine := padLineToWidth(statusText, contentWidth)
-			componentSB.WriteString(paddedLine + "\n")
-		}
-
-		incidentsSB := strings.Builder{}
-		incidents := summary.Incidents
-		if len(incidents) > 0 {
-			incidentURL = fmt.Sprintf("https://www.githubstatus.com/incidents/%s", incidents[0].ID)
-			for _, incident := range incidents[0].IncidentUpdates {
-				wrappedText := wordwrap.WrapString(fmt.Sprintf("Updated %s - %s", incident.Timestamp.Local().Format("2006-01-02 3:04 PM"), incident.Update), uint(contentWidth))
-				// Pad each wrapped line
-				lines := strings.Split(wrappedText, "\n")
-				for _, line := range lines {
-					if line != "" {
-						paddedLine := padLineToWidth(line, contentWidth)
-						incidentsSB.WriteString(paddedLine + "\n")
-					}
-				}
-			}
-			outputIncidents = true
-		}
-
-		// Create boxes - content is now padded to terminal width
-		outputComponentsBox = pterm.DefaultBox.WithTitle("System Status").WithTitleTopCenter().Sprint(componentSB.String())
-		outputIncidentsBox = pterm.DefaultBox.WithTitle("Incident Updates").WithTitleTopCenter().Sprint(incidentsSB.String())
-	}
-
-	// Build output to fill terminal height
-	var output strings.Builder
-	output.WriteString(updateTime)
-
-	if outputError {
-		output.WriteString(errMsg)
-	} else if outputIncidents {
-		output.WriteString(outputComponentsBox)
-		output.WriteString("\n")
-		output.WriteString(incidentURL)
-		output.WriteString("\n")
-		output.WriteString(outputIncidentsBox)
-	} else {
-		output.WriteString(outputComponentsBox)
-	}
-
-	// Add padding to fill remaining terminal height
-	currentLines := strings.Count(output.String(), "\n") + 1
-	linesNeeded := termHeight - currentLines
-
-	if watch {
-		// In watch mode, add help text at bottom with blank line separator
-		// Calculate how much space we have: termHeight - currentLines - 1 (for help text)
-		linesNeeded -= 1
-		if linesNeeded > 0 {
-			// Add padding + blank line separator
-			output.WriteString(strings.Repeat("\n", linesNeeded))
-		}
-		// Add help text at the bottom (no trailing newline)
-		output.WriteString(pterm.Gray("Press 'r' to refresh, 'q' or Ctrl+C to quit"))
-	} else {
-		// Normal mode: fill to terminal height
-		if currentLines < termHeight {
-			padding := strings.Repeat("\n", linesNeeded)
-			output.WriteString(padding)
-		}
-	}
-
-	return output.String()
-}
-
 var rootCmd = &cobra.Command{
 	Use:   "gh-status",
 	Short: "Check the status of github.com",
@@ -296,33 +277,89 @@ gh extension upgrade gh-gh-status
 		if err != nil {
 			log.Fatal(err)
 		}
-		area, _ := pterm.DefaultArea.WithFullscreen(true).Start()
-		client := status.NewClient()
+		client, err := clientFromFlags(cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			log.Fatal(err)
+		}
+		plain, err := cmd.Flags().GetBool("plain")
+		if err != nil {
+			log.Fatal(err)
+		}
+		format = selectFormat(format, cmd.Flags().Changed("format"), plain, stdoutIsTerminal())
+		if colorDisabled() {
+			pterm.DisableColor()
+		}
+		newRenderer, ok := renderers[format]
+		if !ok {
+			log.Fatalf("unknown format %q, expected one of: %s", format, strings.Join(formatNames(), ", "))
+		}
+		themeName, err := cmd.Flags().GetString("theme")
+		if err != nil {
+			log.Fatal(err)
+		}
+		symbolsName, err := cmd.Flags().GetString("symbols")
+		if err != nil {
+			log.Fatal(err)
+		}
+		timeZone, err := cmd.Flags().GetString("tz")
+		if err != nil {
+			log.Fatal(err)
+		}
+		timeFormat, err := cmd.Flags().GetString("time-format")
+		if err != nil {
+			log.Fatal(err)
+		}
+		options, err := newRenderOptions(themeName, symbolsName, timeZone, timeFormat)
+		if err != nil {
+			log.Fatal(err)
+		}
+		renderer := newRenderer(options)
+
+		// Only the fullscreen TUI redraws in place, every other format is streamed to stdout
+		// so it can be piped into other tools
+		var out display = streamDisplay{w: os.Stdout}
+		sigChan := make(chan os.Signal, 1)
+		if format == "tui" {
+			area, _ := pterm.DefaultArea.WithFullscreen(true).Start()
+			defer area.Stop()
+			out = area
+			// Set up signal handler for terminal resize
+			signal.Notify(sigChan, syscall.SIGWINCH)
+		}
+
+		// Cancelled on exit to abort any request that is still in flight
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// The watcher polls immediately and then every minute, in run once mode only the
+		// first poll is used
+		watcher := status.NewWatcher(client, time.Minute)
+		events, _ := watcher.Subscribe(1)
+		go watcher.Run(ctx)
 
 		// Initialize state
 		state := &eventLoopState{
 			currentSummary: nil,
-			outputError:    false,
-			errMsg:         "",
+			lastErr:        nil,
 			lastUpdate:     time.Now(),
+			history:        componentHistory{},
+			changes:        newHighlights(),
 		}
 
-		// Set up signal handler for terminal resize and interrupt
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGWINCH)
-
+		// Set up signal handler for interrupt
 		intChan := make(chan os.Signal, 1)
 		signal.Notify(intChan, os.Interrupt, syscall.SIGTERM)
 
 		// Channels for coordinating updates
-		pollChan := make(chan bool, 1)    // Channel for data polling
 		resizeChan := make(chan bool, 1)  // Channel for resize events
 		refreshChan := make(chan bool, 1) // Channel for manual refresh
-		ticker := time.NewTicker(time.Minute)
-		defer ticker.Stop()
-
-		// Initial data poll
-		pollChan <- true
+		toggleLog := make(chan bool, 1)   // Channel for showing or hiding the session log
+		pause := make(chan bool, 1)       // Channel for pausing or resuming polling
+		interval := make(chan int, 1)     // Channel for polling more or less often
 
 		// Main event loop
 		done := make(chan bool, 1)
@@ -334,24 +371,48 @@ gh extension upgrade gh-gh-status
 			oldTermState, err = setNonCanonicalMode(int(os.Stdin.Fd()))
 			if err == nil {
 				defer term.Restore(int(os.Stdin.Fd()), oldTermState)
+				// Pausing, changing the interval and the session log only show in the fullscreen
+				// view, other formats would print the whole report again for each key
+				keyToggleLog, keyPause, keyInterval := toggleLog, pause, interval
+				if format != "tui" {
+					keyToggleLog, keyPause, keyInterval = nil, nil, nil
+				}
 				// Start keyboard input handler
-				go handleKeyboardInput(refreshChan, done)
+				go handleKeyboardInput(refreshChan, keyToggleLog, keyPause, keyInterval, done)
 			}
 		}
 
+		// The fullscreen view counts down to the next poll
+		var countdown <-chan time.Time
+		if format == "tui" && watch {
+			countdownTicker := time.NewTicker(time.Second)
+			defer countdownTicker.Stop()
+			countdown = countdownTicker.C
+		}
+
 		params := eventLoopParams{
-			client:       client,
-			area:         area,
+			ctx:          ctx,
+			watcher:      watcher,
+			events:       events,
+			renderer:     renderer,
+			display:      out,
 			watch:        watch,
 			sigChan:      sigChan,
-			pollChan:     pollChan,
 			resizeChan:   resizeChan,
 			refreshChan:  refreshChan,
-			ticker:       ticker,
+			toggleLog:    toggleLog,
+			pause:        pause,
+			interval:     interval,
+			countdown:    countdown,
 			done:         done,
 			currentState: state,
 		}
-		go runEventLoop(params)
+		// Closed once the event loop has returned, so its state is safe to read
+		loopDone := make(chan struct{})
+		go func() {
+			defer close(loopDone)
+			runEventLoop(params)
+		}()
 
 		// Handle interrupt signal
 		go func() {
@@ -361,16 +422,63 @@ gh extension upgrade gh-gh-status
 
 		// Wait for completion
 		<-done
-		area.Stop()
+		cancel()
+		<-loopDone
+		if !watch {
+			exitCode = healthExitCode(state.currentSummary, state.lastErr)
+		}
 	},
 }
 
+const (
+	// exitFailed is the exit code when the status couldn't be retrieved, matching log.Fatal
+	exitFailed = 1
+	// exitMajorOutage is the exit code when running once finds a major or critical outage
+	exitMajorOutage = 2
+	// exitInterrupted is the exit code when running once is interrupted before the first poll
+	// finishes, the shell's convention for Ctrl+C
+	exitInterrupted = 130
+)
+
+// exitCode is set by a command to exit with once it has cleaned up
+var exitCode int
+
+// healthExitCode is the exit code for the overall status found when running once, so scripts
+// can check whether GitHub is usable without parsing the output
+func healthExitCode(summary *status.SystemStatus, err error) int {
+	if summary == nil {
+		if err == nil {
+			// Nothing was retrieved, so it was interrupted before the first poll finished
+			return exitInterrupted
+		}
+		return exitFailed
+	}
+	if summary.Overall().Indicator.Severity() >= status.INDICATOR_MAJOR.Severity() {
+		return exitMajorOutage
+	}
+	return 0
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	cobra.CheckErr(rootCmd.Execute())
+	os.Exit(exitCode)
 }
 
 func init() {
 	rootCmd.Flags().BoolP("watch", "w", false, "Check for a status update every minute")
+	rootCmd.Flags().String("format", "tui", fmt.Sprintf("Output format, one of: %s", strings.Join(formatNames(), ", ")))
+	rootCmd.Flags().Bool("plain", false, "Print an uncolored report without redrawing the screen, the default when not writing to a terminal")
+	rootCmd.MarkFlagsMutuallyExclusive("plain", "format")
+	rootCmd.Flags().String("theme", envOrDefault("GH_STATUS_THEME", defaultTheme), fmt.Sprintf("Color theme, one of: %s (or set GH_STATUS_THEME)", strings.Join(themeNames(), ", ")))
+	rootCmd.Flags().String("symbols", envOrDefault("GH_STATUS_SYMBOLS", ""), fmt.Sprintf("Symbols marking each status, one of: %s (or set GH_STATUS_SYMBOLS), defaults to the theme's choice", strings.Join(symbolSetNames(), ", ")))
+	rootCmd.PersistentFlags().String("tz", "", "Time zone to show times in, such as UTC or America/New_York, defaults to local time")
+	rootCmd.PersistentFlags().String("time-format", defaultTimeFormat, fmt.Sprintf("How to show times, one of: %s", strings.Join(timeFormatNames(), ", ")))
+	rootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "Maximum time to wait for a response from githubstatus.com")
+	rootCmd.PersistentFlags().Int("retries", 3, "How many times to retry a request that times out, loses its connection or gets an error from githubstatus.com, waiting longer between each")
+	rootCmd.PersistentFlags().String("proxy", "", "Proxy to send requests through, such as http://proxy.example.com:3128, defaults to HTTPS_PROXY")
+	rootCmd.PersistentFlags().String("ca-file", os.Getenv("SSL_CERT_FILE"), "PEM file of extra CA certificates to trust, such as the CA of a proxy that intercepts TLS, defaults to SSL_CERT_FILE")
+	rootCmd.PersistentFlags().String("client-cert", "", "PEM file of a client certificate to present, used with --client-key")
+	rootCmd.PersistentFlags().String("client-key", "", "PEM file of the private key for --client-cert")
 }
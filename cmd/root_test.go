@@ -1,9 +1,8 @@
 package cmd
 
 import (
-	"strings"
+	"errors"
 	"testing"
-	"time"
 
 	"github.com/wwsean08/gh-gh-status/status"
 )
@@ -59,6 +58,31 @@ func TestStripAnsiCodes(t *testing.T) {
 			input:    "Before\x1b[32mAfter",
 			expected: "BeforeAfter",
 		},
+		{
+			name:     "OSC 8 hyperlink",
+			input:    "See \x1b]8;;https://github.blog\x1b\\the blog\x1b]8;;\x1b\\ now",
+			expected: "See the blog now",
+		},
+		{
+			name:     "OSC 8 hyperlink terminated by BEL",
+			input:    "\x1b]8;;https://github.blog\x07blog\x1b]8;;\x07",
+			expected: "blog",
+		},
+		{
+			name:     "cursor movement and erase sequences",
+			input:    "\x1b[2K\x1b[1;1HTop\x1b[?25l",
+			expected: "Top",
+		},
+		{
+			name:     "OSC window title",
+			input:    "\x1b]0;gh-status\x07Status",
+			expected: "Status",
+		},
+		{
+			name:     "two byte escape",
+			input:    "\x1bMUp",
+			expected: "Up",
+		},
 		{
 			name:     "multiple consecutive ANSI codes",
 			input:    "\x1b[1m\x1b[32m\x1b[40mText\x1b[0m",
@@ -149,6 +173,36 @@ func TestPadLineToWidth(t *testing.T) {
 			width:    20,
 			expected: "Start\x1b[32mMiddle\x1b[0mEnd      ",
 		},
+		{
+			name:     "wide characters take two cells",
+			line:     "日本語",
+			width:    10,
+			expected: "日本語    ",
+		},
+		{
+			name:     "emoji take two cells",
+			line:     "🚀 Deploy",
+			width:    10,
+			expected: "🚀 Deploy ",
+		},
+		{
+			name:     "accented characters take one cell",
+			line:     "Café",
+			width:    6,
+			expected: "Café  ",
+		},
+		{
+			name:     "combining marks take no cells",
+			line:     "Cafe\u0301",
+			width:    6,
+			expected: "Cafe\u0301  ",
+		},
+		{
+			name:     "hyperlinks take no cells",
+			line:     "\x1b]8;;https://github.com\x1b\\GitHub\x1b]8;;\x1b\\",
+			width:    8,
+			expected: "\x1b]8;;https://github.com\x1b\\GitHub\x1b]8;;\x1b\\  ",
+		},
 		{
 			name:     "exact fit with ANSI codes",
 			line:     "\x1b[1m\x1b[32mHello\x1b[0m",
@@ -164,10 +218,9 @@ func TestPadLineToWidth(t *testing.T) {
 				t.Errorf("padLineToWidth(%q, %d) = %q, expected %q", tt.line, tt.width, result, tt.expected)
 			}
 			// Verify the visual length (without ANSI codes) matches or exceeds width
-			strippedResult := stripAnsiCodes(result)
-			if len(strippedResult) < tt.width && len(stripAnsiCodes(tt.line)) < tt.width {
+			if displayWidth(result) < tt.width && displayWidth(tt.line) < tt.width {
 				t.Errorf("padLineToWidth(%q, %d) resulted in visual length %d, expected at least %d",
-					tt.line, tt.width, len(strippedResult), tt.width)
+					tt.line, tt.width, displayWidth(result), tt.width)
 			}
 		})
 	}
@@ -211,308 +264,32 @@ func TestStripAnsiCodesIdempotent(t *testing.T) {
 	}
 }
 
-func TestRenderUI_NilSummary(t *testing.T) {
-	// Test rendering with nil summary
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	result := renderUI(nil, false, "", lastUpdate, false)
-
-	// Should contain last updated time
-	if !strings.Contains(result, "Last Updated") {
-		t.Error("Expected output to contain 'Last Updated'")
-	}
-
-	// Should not be empty
-	if result == "" {
-		t.Error("Expected non-empty output for nil summary")
-	}
-}
-
-func TestRenderUI_WithError(t *testing.T) {
-	// Test rendering with error state
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	errMsg := "Failed to fetch status"
-	result := renderUI(nil, true, errMsg, lastUpdate, false)
-
-	// Should contain error message
-	if !strings.Contains(result, errMsg) {
-		t.Errorf("Expected output to contain error message %q", errMsg)
-	}
-
-	// Should contain last updated time
-	if !strings.Contains(result, "Last Updated") {
-		t.Error("Expected output to contain 'Last Updated'")
-	}
-}
-
-func TestRenderUI_WithComponents(t *testing.T) {
-	// Create test data with components
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	summary := &status.SystemStatus{
-		Components: []status.Components{
-			{
-				ID:        "comp1",
-				Component: "Git Operations",
-				Status:    status.COMPONENT_OPERATIONAL,
-			},
-			{
-				ID:        "comp2",
-				Component: "API Requests",
-				Status:    status.COMPONENT_DEGREDADED_PERFORMANCE,
-			},
-		},
-		Incidents: []status.Incidents{},
-	}
-
-	result := renderUI(summary, false, "", lastUpdate, false)
-
-	// Should contain component names
-	if !strings.Contains(result, "Git Operations") {
-		t.Error("Expected output to contain 'Git Operations'")
-	}
-	if !strings.Contains(result, "API Requests") {
-		t.Error("Expected output to contain 'API Requests'")
-	}
-
-	// Should contain status information
-	if !strings.Contains(result, "Operational") {
-		t.Error("Expected output to contain 'Operational'")
-	}
-	if !strings.Contains(result, "Degraded Performance") {
-		t.Error("Expected output to contain 'Degraded Performance'")
-	}
-
-	// Should contain System Status box title
-	if !strings.Contains(result, "System Status") {
-		t.Error("Expected output to contain 'System Status' box title")
-	}
-}
-
-func TestRenderUI_FilterIgnoredComponent(t *testing.T) {
-	// Test that ignored component is filtered out
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	summary := &status.SystemStatus{
-		Components: []status.Components{
-			{
-				ID:        IGNORE_GHSTATUS_COMPONENTID,
-				Component: "Visit www.githubstatus.com",
-				Status:    status.COMPONENT_OPERATIONAL,
-			},
-			{
-				ID:        "comp1",
-				Component: "Git Operations",
-				Status:    status.COMPONENT_OPERATIONAL,
-			},
-		},
-		Incidents: []status.Incidents{},
-	}
-
-	result := renderUI(summary, false, "", lastUpdate, false)
-
-	// Should NOT contain ignored component
-	if strings.Contains(result, "Visit www.githubstatus.com") {
-		t.Error("Expected output to NOT contain ignored component")
-	}
-
-	// Should contain the other component
-	if !strings.Contains(result, "Git Operations") {
-		t.Error("Expected output to contain 'Git Operations'")
-	}
-}
-
-func TestRenderUI_AllComponentStatuses(t *testing.T) {
-	// Test all different component status types
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	summary := &status.SystemStatus{
-		Components: []status.Components{
-			{
-				ID:        "comp1",
-				Component: "Service A",
-				Status:    status.COMPONENT_OPERATIONAL,
-			},
-			{
-				ID:        "comp2",
-				Component: "Service B",
-				Status:    status.COMPONENT_DEGREDADED_PERFORMANCE,
-			},
-			{
-				ID:        "comp3",
-				Component: "Service C",
-				Status:    status.COMPONENT_PARTIAL_OUTAGE,
-			},
-			{
-				ID:        "comp4",
-				Component: "Service D",
-				Status:    status.COMPONENT_MAJOR_OUTAGE,
-			},
-		},
-		Incidents: []status.Incidents{},
-	}
-
-	result := renderUI(summary, false, "", lastUpdate, false)
-
-	// Check all status types are rendered
-	statusChecks := map[string]string{
-		"Service A": "Operational",
-		"Service B": "Degraded Performance",
-		"Service C": "Partial Outage",
-		"Service D": "Major Outage",
-	}
-
-	for service, statusText := range statusChecks {
-		if !strings.Contains(result, service) {
-			t.Errorf("Expected output to contain %q", service)
-		}
-		if !strings.Contains(result, statusText) {
-			t.Errorf("Expected output to contain status %q", statusText)
-		}
-	}
-}
-
-func TestRenderUI_WithIncidents(t *testing.T) {
-	// Test rendering with incidents
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	incidentTime := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
-
-	summary := &status.SystemStatus{
-		Components: []status.Components{
-			{
-				ID:        "comp1",
-				Component: "Git Operations",
-				Status:    status.COMPONENT_OPERATIONAL,
-			},
-		},
-		Incidents: []status.Incidents{
-			{
-				ID:     "incident123",
-				Status: "investigating",
-				IncidentUpdates: []status.IncidentUpdate{
-					{
-						Status: "investigating",
-						Update: "We are investigating degraded performance",
-						Timestamp: &status.Time{
-							Time: &incidentTime,
-						},
-					},
-				},
-			},
+func TestHealthExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  *status.SystemStatus
+		err      error
+		expected int
+	}{
+		{name: "interrupted before the first poll", summary: nil, expected: exitInterrupted},
+		{name: "poll failed", summary: nil, err: errors.New("connection refused"), expected: exitFailed},
+		{name: "operational", summary: &status.SystemStatus{Status: status.PageStatus{Indicator: status.INDICATOR_NONE}}, expected: 0},
+		{name: "minor", summary: &status.SystemStatus{Status: status.PageStatus{Indicator: status.INDICATOR_MINOR}}, expected: 0},
+		{name: "major", summary: &status.SystemStatus{Status: status.PageStatus{Indicator: status.INDICATOR_MAJOR}}, expected: exitMajorOutage},
+		{name: "critical", summary: &status.SystemStatus{Status: status.PageStatus{Indicator: status.INDICATOR_CRITICAL}}, expected: exitMajorOutage},
+		{
+			name: "major outage without an indicator",
+			summary: &status.SystemStatus{Components: []status.Components{
+				{ID: "1", Component: "Actions", Status: status.COMPONENT_MAJOR_OUTAGE},
+			}},
+			expected: exitMajorOutage,
 		},
 	}
-
-	result := renderUI(summary, false, "", lastUpdate, false)
-
-	// Should contain incident URL
-	if !strings.Contains(result, "githubstatus.com/incidents/incident123") {
-		t.Error("Expected output to contain incident URL")
-	}
-
-	// Should contain incident update text
-	if !strings.Contains(result, "investigating degraded performance") {
-		t.Error("Expected output to contain incident update text")
-	}
-
-	// Should contain Incident Updates box title
-	if !strings.Contains(result, "Incident Updates") {
-		t.Error("Expected output to contain 'Incident Updates' box title")
-	}
-
-	// Should contain both System Status and Incident Updates boxes
-	if !strings.Contains(result, "System Status") {
-		t.Error("Expected output to contain 'System Status' box title")
-	}
-}
-
-func TestRenderUI_EmptyComponents(t *testing.T) {
-	// Test with empty components list
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	summary := &status.SystemStatus{
-		Components: []status.Components{},
-		Incidents:  []status.Incidents{},
-	}
-
-	result := renderUI(summary, false, "", lastUpdate, false)
-
-	// Should not crash and should contain basic elements
-	if !strings.Contains(result, "Last Updated") {
-		t.Error("Expected output to contain 'Last Updated'")
-	}
-
-	// Should contain System Status box even if empty
-	if !strings.Contains(result, "System Status") {
-		t.Error("Expected output to contain 'System Status' box title")
-	}
-}
-
-func TestRenderUI_ContainsNewlines(t *testing.T) {
-	// Test that output contains newlines for padding
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	summary := &status.SystemStatus{
-		Components: []status.Components{
-			{
-				ID:        "comp1",
-				Component: "Test Component",
-				Status:    status.COMPONENT_OPERATIONAL,
-			},
-		},
-		Incidents: []status.Incidents{},
-	}
-
-	result := renderUI(summary, false, "", lastUpdate, false)
-
-	// Should contain multiple newlines for terminal height padding
-	newlineCount := strings.Count(result, "\n")
-	if newlineCount < 5 {
-		t.Errorf("Expected at least 5 newlines for padding, got %d", newlineCount)
-	}
-}
-
-func TestRenderUI_ConsistentOutput(t *testing.T) {
-	// Test that calling renderUI twice with same inputs gives consistent results
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	summary := &status.SystemStatus{
-		Components: []status.Components{
-			{
-				ID:        "comp1",
-				Component: "Test",
-				Status:    status.COMPONENT_OPERATIONAL,
-			},
-		},
-		Incidents: []status.Incidents{},
-	}
-
-	result1 := renderUI(summary, false, "", lastUpdate, false)
-	result2 := renderUI(summary, false, "", lastUpdate, false)
-
-	if result1 != result2 {
-		t.Error("renderUI should produce consistent output for same inputs")
-	}
-}
-
-func TestRenderUI_WatchModeHelpText(t *testing.T) {
-	// Test that watch mode includes help text at bottom
-	lastUpdate := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
-	summary := &status.SystemStatus{
-		Components: []status.Components{
-			{
-				ID:        "comp1",
-				Component: "Test Component",
-				Status:    status.COMPONENT_OPERATIONAL,
-			},
-		},
-		Incidents: []status.Incidents{},
-	}
-
-	// Test without watch mode
-	resultNoWatch := renderUI(summary, false, "", lastUpdate, false)
-	if strings.Contains(resultNoWatch, "Press 'r' to refresh") {
-		t.Error("Expected no help text when watch mode is disabled")
-	}
-
-	// Test with watch mode
-	resultWatch := renderUI(summary, false, "", lastUpdate, true)
-	if !strings.Contains(resultWatch, "Press 'r' to refresh") {
-		t.Error("Expected help text to contain 'Press 'r' to refresh' in watch mode")
-	}
-	if !strings.Contains(resultWatch, "quit") {
-		t.Error("Expected help text to mention quit option in watch mode")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := healthExitCode(tt.summary, tt.err); result != tt.expected {
+				t.Errorf("healthExitCode() = %d, expected %d", result, tt.expected)
+			}
+		})
 	}
 }
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/pterm/pterm"
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// styler colors text, the way pterm.Color.Sprint and pterm.Style.Sprint do
+type styler func(a ...any) string
+
+// symbolSet maps each component status to the symbol shown before it
+type symbolSet map[status.ComponentStatus]string
+
+// theme decides how each component status is colored and which symbols are used by default
+type theme struct {
+	styles  map[status.ComponentStatus]styler
+	symbols string // name of the symbol set used unless --symbols says otherwise
+}
+
+// unknownSymbol marks a component whose status isn't known to this extension
+const unknownSymbol = "?"
+
+var symbolSets = map[string]symbolSet{
+	"none": {},
+	"unicode": {
+		status.COMPONENT_OPERATIONAL:            "✔",
+		status.COMPONENT_UNDER_MAINTENANCE:      "◆",
+		status.COMPONENT_DEGREDADED_PERFORMANCE: "◐",
+		status.COMPONENT_PARTIAL_OUTAGE:         "▲",
+		status.COMPONENT_MAJOR_OUTAGE:           "✖",
+	},
+	"ascii": {
+		status.COMPONENT_OPERATIONAL:            "+",
+		status.COMPONENT_UNDER_MAINTENANCE:      "m",
+		status.COMPONENT_DEGREDADED_PERFORMANCE: "~",
+		status.COMPONENT_PARTIAL_OUTAGE:         "!",
+		status.COMPONENT_MAJOR_OUTAGE:           "x",
+	},
+}
+
+var themes = map[string]theme{
+	"default": {
+		styles: map[status.ComponentStatus]styler{
+			status.COMPONENT_OPERATIONAL:            pterm.Green,
+			status.COMPONENT_UNDER_MAINTENANCE:      pterm.Blue,
+			status.COMPONENT_DEGREDADED_PERFORMANCE: pterm.LightYellow,
+			status.COMPONENT_PARTIAL_OUTAGE:         pterm.Yellow,
+			status.COMPONENT_MAJOR_OUTAGE:           pterm.Red,
+		},
+		symbols: "none",
+	},
+	"high-contrast": {
+		styles: map[status.ComponentStatus]styler{
+			status.COMPONENT_OPERATIONAL:            pterm.NewStyle(pterm.FgLightGreen, pterm.Bold).Sprint,
+			status.COMPONENT_UNDER_MAINTENANCE:      pterm.NewStyle(pterm.FgLightCyan, pterm.Bold).Sprint,
+			status.COMPONENT_DEGREDADED_PERFORMANCE: pterm.NewStyle(pterm.FgLightYellow, pterm.Bold).Sprint,
+			status.COMPONENT_PARTIAL_OUTAGE:         pterm.NewStyle(pterm.FgBlack, pterm.BgYellow).Sprint,
+			status.COMPONENT_MAJOR_OUTAGE:           pterm.NewStyle(pterm.FgLightWhite, pterm.BgRed, pterm.Bold).Sprint,
+		},
+		symbols: "unicode",
+	},
+	// Colors from the Okabe-Ito palette which stay distinguishable with color vision deficiencies
+	"colorblind-safe": {
+		styles: map[status.ComponentStatus]styler{
+			status.COMPONENT_OPERATIONAL:            pterm.NewRGB(0, 114, 178).Sprint,
+			status.COMPONENT_UNDER_MAINTENANCE:      pterm.NewRGB(86, 180, 233).Sprint,
+			status.COMPONENT_DEGREDADED_PERFORMANCE: pterm.NewRGB(240, 228, 66).Sprint,
+			status.COMPONENT_PARTIAL_OUTAGE:         pterm.NewRGB(230, 159, 0).Sprint,
+			status.COMPONENT_MAJOR_OUTAGE:           pterm.NewRGB(213, 94, 0).Sprint,
+		},
+		symbols: "unicode",
+	},
+	"monochrome": {
+		styles: map[status.ComponentStatus]styler{
+			status.COMPONENT_MAJOR_OUTAGE: pterm.Bold.Sprint,
+		},
+		symbols: "unicode",
+	},
+}
+
+// defaultTheme is used when neither --theme nor GH_STATUS_THEME is set
+const defaultTheme = "default"
+
+// themeNames returns the names of every theme, sorted for help text
+func themeNames() []string {
+	return sortedKeys(themes)
+}
+
+// symbolSetNames returns the names of every symbol set, sorted for help text
+func symbolSetNames() []string {
+	return sortedKeys(symbolSets)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// envOrDefault returns the value of the environment variable key, or fallback if it is unset
+func envOrDefault(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// statusTheme is a theme combined with the symbols chosen for it
+type statusTheme struct {
+	styles  map[status.ComponentStatus]styler
+	symbols symbolSet
+}
+
+// newStatusTheme looks up a theme and symbol set by name, an empty symbol set name uses the
+// theme's own choice
+func newStatusTheme(themeName string, symbolsName string) (statusTheme, error) {
+	t, ok := themes[themeName]
+	if !ok {
+		return statusTheme{}, fmt.Errorf("unknown theme %q, expected one of: %v", themeName, themeNames())
+	}
+	if symbolsName == "" {
+		symbolsName = t.symbols
+	}
+	symbols, ok := symbolSets[symbolsName]
+	if !ok {
+		return statusTheme{}, fmt.Errorf("unknown symbols %q, expected one of: %v", symbolsName, symbolSetNames())
+	}
+	return statusTheme{styles: t.styles, symbols: symbols}, nil
+}
+
+// symbol returns the symbol for a component status followed by a space, or nothing if the
+// symbol set is empty
+func (t statusTheme) symbol(componentStatus status.ComponentStatus) string {
+	if len(t.symbols) == 0 {
+		return ""
+	}
+	symbol, ok := t.symbols[componentStatus]
+	if !ok {
+		symbol = unknownSymbol
+	}
+	return symbol + " "
+}
+
+// style colors text according to a component status, leaving it alone if the theme has no
+// color for that status
+func (t statusTheme) style(componentStatus status.ComponentStatus, text string) string {
+	if style, ok := t.styles[componentStatus]; ok {
+		return style(text)
+	}
+	return text
+}
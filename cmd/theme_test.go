@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+func TestNewStatusTheme_UnknownNames(t *testing.T) {
+	if _, err := newStatusTheme("sparkly", ""); err == nil {
+		t.Error("expected an error for an unknown theme")
+	}
+	if _, err := newStatusTheme(defaultTheme, "emoji"); err == nil {
+		t.Error("expected an error for an unknown symbol set")
+	}
+}
+
+func TestNewStatusTheme_Symbols(t *testing.T) {
+	tests := []struct {
+		theme    string
+		symbols  string
+		expected string
+	}{
+		{theme: "default", symbols: "", expected: ""},
+		{theme: "monochrome", symbols: "", expected: "✖ "},
+		{theme: "colorblind-safe", symbols: "ascii", expected: "x "},
+		{theme: "high-contrast", symbols: "none", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.theme+"/"+tt.symbols, func(t *testing.T) {
+			theme, err := newStatusTheme(tt.theme, tt.symbols)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := theme.symbol(status.COMPONENT_MAJOR_OUTAGE)
+			if result != tt.expected {
+				t.Errorf("symbol() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStatusTheme_EveryStatusHasDistinctSymbol(t *testing.T) {
+	statuses := []status.ComponentStatus{
+		status.COMPONENT_OPERATIONAL,
+		status.COMPONENT_UNDER_MAINTENANCE,
+		status.COMPONENT_DEGREDADED_PERFORMANCE,
+		status.COMPONENT_PARTIAL_OUTAGE,
+		status.COMPONENT_MAJOR_OUTAGE,
+	}
+	for _, name := range []string{"unicode", "ascii"} {
+		seen := map[string]bool{}
+		for _, componentStatus := range statuses {
+			symbol, ok := symbolSets[name][componentStatus]
+			if !ok {
+				t.Errorf("symbol set %s has no symbol for %s", name, componentStatus)
+			}
+			if seen[symbol] {
+				t.Errorf("symbol set %s uses %q more than once", name, symbol)
+			}
+			seen[symbol] = true
+		}
+	}
+}
+
+func TestStatusTheme_UnknownStatus(t *testing.T) {
+	theme, err := newStatusTheme("monochrome", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.symbol("exploded") != unknownSymbol+" " {
+		t.Error("expected unknown statuses to use the unknown symbol")
+	}
+	if theme.style("exploded", "text") != "text" {
+		t.Error("expected unknown statuses to be left unstyled")
+	}
+}
+
+func TestTuiRenderer_UsesThemeSymbols(t *testing.T) {
+	options, err := newRenderOptions("monochrome", "", "", defaultTimeFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := tuiRenderer{options: options}.Render(goldenSnapshot(), 80, 24)
+	for _, expected := range []string{"✔ Git Operations", "◐ API Requests", "▲ Actions", "✖ Pages"} {
+		if !strings.Contains(stripAnsiCodes(result), expected) {
+			t.Errorf("expected output to contain %q", expected)
+		}
+	}
+}
+
+func TestEnvOrDefault(t *testing.T) {
+	t.Setenv("GH_STATUS_THEME", "")
+	if envOrDefault("GH_STATUS_THEME", "default") != "default" {
+		t.Error("expected the fallback when the variable is empty")
+	}
+	t.Setenv("GH_STATUS_THEME", "monochrome")
+	if envOrDefault("GH_STATUS_THEME", "default") != "monochrome" {
+		t.Error("expected the value of the variable when it is set")
+	}
+}
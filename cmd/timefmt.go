@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+// timeFormats are the layouts that can be selected with --time-format, each with the layout
+// for a time of day and for a full timestamp. The relative format has no layouts.
+var timeFormats = map[string]struct {
+	clock     string
+	timestamp string
+}{
+	"12h":      {clock: "3:04 PM", timestamp: "2006-01-02 3:04 PM"},
+	"24h":      {clock: "15:04", timestamp: "2006-01-02 15:04"},
+	"iso8601":  {clock: time.RFC3339, timestamp: time.RFC3339},
+	"relative": {},
+}
+
+// defaultTimeFormat is the format used when --time-format isn't passed
+const defaultTimeFormat = "12h"
+
+// timeFormatNames returns the names of every time format, sorted for help text
+func timeFormatNames() []string {
+	return sortedKeys(timeFormats)
+}
+
+// timeFormatter formats times in the time zone and format chosen by the user. The zero value
+// formats in the local time zone using the 12 hour clock.
+type timeFormatter struct {
+	location *time.Location
+	format   string
+	now      func() time.Time // the current time for relative formatting, time.Now if nil
+}
+
+// newTimeFormatter creates a timeFormatter from the time zone name, such as "UTC" or
+// "America/New_York", and the name of a time format. An empty time zone means local time.
+func newTimeFormatter(timeZone string, format string) (timeFormatter, error) {
+	if _, ok := timeFormats[format]; !ok {
+		return timeFormatter{}, fmt.Errorf("unknown time format %q, expected one of: %v", format, timeFormatNames())
+	}
+	location := time.Local
+	if timeZone != "" {
+		var err error
+		location, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return timeFormatter{}, fmt.Errorf("unknown time zone %q: %w", timeZone, err)
+		}
+	}
+	return timeFormatter{location: location, format: format}, nil
+}
+
+// in converts t to the chosen time zone
+func (f timeFormatter) in(t time.Time) time.Time {
+	if f.location == nil {
+		return t.Local()
+	}
+	return t.In(f.location)
+}
+
+// clock formats the time of day, such as when the status was last updated
+func (f timeFormatter) clock(t time.Time) string {
+	if f.format == "relative" {
+		return f.relative(t)
+	}
+	layout := timeFormats[f.format].clock
+	if layout == "" {
+		layout = timeFormats[defaultTimeFormat].clock
+	}
+	return f.in(t).Format(layout)
+}
+
+// timestamp formats a full date and time, such as when an incident update was posted
+func (f timeFormatter) timestamp(t time.Time) string {
+	if f.format == "relative" {
+		return f.relative(t)
+	}
+	layout := timeFormats[f.format].timestamp
+	if layout == "" {
+		layout = timeFormats[defaultTimeFormat].timestamp
+	}
+	return f.in(t).Format(layout)
+}
+
+// statusTime formats a timestamp from Statuspage, which may be missing
+func (f timeFormatter) statusTime(t *status.Time) string {
+	if t == nil || t.Time == nil {
+		return ""
+	}
+	return f.timestamp(*t.Time)
+}
+
+// current returns the current time, which tests can fix
+func (f timeFormatter) current() time.Time {
+	if f.now != nil {
+		return f.now()
+	}
+	return time.Now()
+}
+
+// relative describes how long ago t was, such as "12 min ago"
+func (f timeFormatter) relative(t time.Time) string {
+	elapsed := f.current().Sub(t)
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%d min ago", int(elapsed.Minutes()))
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%d hr ago", int(elapsed.Hours()))
+	case elapsed < 48*time.Hour:
+		return "1 day ago"
+	default:
+		return fmt.Sprintf("%d days ago", int(elapsed.Hours()/24))
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wwsean08/gh-gh-status/status"
+)
+
+func TestNewTimeFormatter_Errors(t *testing.T) {
+	if _, err := newTimeFormatter("Mars/Olympus_Mons", defaultTimeFormat); err == nil {
+		t.Error("expected an error for an unknown time zone")
+	}
+	if _, err := newTimeFormatter("UTC", "sundial"); err == nil {
+		t.Error("expected an error for an unknown time format")
+	}
+}
+
+func TestTimeFormatter_Formats(t *testing.T) {
+	moment := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		timeZone  string
+		format    string
+		clock     string
+		timestamp string
+	}{
+		{timeZone: "UTC", format: "12h", clock: "2:30 PM", timestamp: "2024-01-15 2:30 PM"},
+		{timeZone: "UTC", format: "24h", clock: "14:30", timestamp: "2024-01-15 14:30"},
+		{timeZone: "America/New_York", format: "24h", clock: "09:30", timestamp: "2024-01-15 09:30"},
+		{timeZone: "Asia/Kolkata", format: "iso8601", clock: "2024-01-15T20:00:00+05:30", timestamp: "2024-01-15T20:00:00+05:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeZone+"/"+tt.format, func(t *testing.T) {
+			formatter, err := newTimeFormatter(tt.timeZone, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result := formatter.clock(moment); result != tt.clock {
+				t.Errorf("clock() = %q, expected %q", result, tt.clock)
+			}
+			if result := formatter.timestamp(moment); result != tt.timestamp {
+				t.Errorf("timestamp() = %q, expected %q", result, tt.timestamp)
+			}
+		})
+	}
+}
+
+func TestTimeFormatter_Relative(t *testing.T) {
+	now := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	formatter := timeFormatter{format: "relative", now: func() time.Time { return now }}
+	tests := []struct {
+		elapsed  time.Duration
+		expected string
+	}{
+		{elapsed: -time.Minute, expected: "just now"},
+		{elapsed: 30 * time.Second, expected: "just now"},
+		{elapsed: 12 * time.Minute, expected: "12 min ago"},
+		{elapsed: 3*time.Hour + 5*time.Minute, expected: "3 hr ago"},
+		{elapsed: 30 * time.Hour, expected: "1 day ago"},
+		{elapsed: 72 * time.Hour, expected: "3 days ago"},
+	}
+
+	for _, tt := range tests {
+		if result := formatter.timestamp(now.Add(-tt.elapsed)); result != tt.expected {
+			t.Errorf("relative time for %s = %q, expected %q", tt.elapsed, result, tt.expected)
+		}
+	}
+}
+
+func TestTimeFormatter_ZeroValue(t *testing.T) {
+	moment := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	// The zero value shows local time
+	expected := moment.Local().Format("2006-01-02 3:04 PM")
+	if result := (timeFormatter{}).timestamp(moment); result != expected {
+		t.Errorf("expected the zero value to use the 12 hour clock, got %q", result)
+	}
+}
+
+func TestTimeFormatter_StatusTime(t *testing.T) {
+	formatter, err := newTimeFormatter("UTC", "24h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := formatter.statusTime(nil); result != "" {
+		t.Errorf("expected an empty string for a missing time, got %q", result)
+	}
+	if result := formatter.statusTime(&status.Time{}); result != "" {
+		t.Errorf("expected an empty string for a null time, got %q", result)
+	}
+	moment := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	if result := formatter.statusTime(&status.Time{Time: &moment}); result != "2024-01-15 14:30" {
+		t.Errorf("statusTime() = %q", result)
+	}
+}
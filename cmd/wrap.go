@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/clipperhouse/uax29/v2/graphemes"
+)
+
+// wrapText wraps s so no line takes up more than width terminal cells. Lines are broken at
+// spaces where possible and words longer than a whole line are split between graphemes.
+// Escape sequences are kept intact and take no space, a width of zero leaves s untouched.
+func wrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	var output strings.Builder
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		wrapLine(&output, line, width)
+	}
+	return output.String()
+}
+
+// wrapLine writes a single line without newlines to output, wrapped to width
+func wrapLine(output *strings.Builder, line string, width int) {
+	lineWidth := 0
+	for i, word := range strings.Split(line, " ") {
+		wordWidth := displayWidth(word)
+		if i > 0 {
+			if lineWidth+1+wordWidth <= width {
+				output.WriteString(" ")
+				lineWidth++
+			} else if lineWidth > 0 {
+				// The space is replaced by the line break
+				output.WriteString("\n")
+				lineWidth = 0
+			}
+		}
+		if lineWidth+wordWidth <= width {
+			output.WriteString(word)
+			lineWidth += wordWidth
+			continue
+		}
+		for _, cell := range splitCells(word) {
+			cellWidth := displayWidth(cell)
+			if lineWidth > 0 && lineWidth+cellWidth > width {
+				output.WriteString("\n")
+				lineWidth = 0
+			}
+			output.WriteString(cell)
+			lineWidth += cellWidth
+		}
+	}
+}
+
+// splitCells splits s into graphemes, the smallest pieces that can be placed on separate
+// lines, keeping each escape sequence whole
+func splitCells(s string) []string {
+	var cells []string
+	addGraphemes := func(text string) {
+		g := graphemes.FromString(text)
+		for g.Next() {
+			cells = append(cells, g.Value())
+		}
+	}
+	last := 0
+	for _, loc := range ansiRegex.FindAllStringIndex(s, -1) {
+		addGraphemes(s[last:loc[0]])
+		cells = append(cells, s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	addGraphemes(s[last:])
+	return cells
+}
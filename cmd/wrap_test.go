@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{
+			name:     "fits on one line",
+			input:    "All systems operational",
+			width:    40,
+			expected: "All systems operational",
+		},
+		{
+			name:     "breaks at spaces",
+			input:    "We are investigating reports of degraded performance",
+			width:    20,
+			expected: "We are investigating\nreports of degraded\nperformance",
+		},
+		{
+			name:     "keeps existing line breaks",
+			input:    "First line\nSecond line",
+			width:    40,
+			expected: "First line\nSecond line",
+		},
+		{
+			name:     "wide characters count as two cells",
+			input:    "日本語 日本語 日本語",
+			width:    14,
+			expected: "日本語 日本語\n日本語",
+		},
+		{
+			name:     "splits long words between characters",
+			input:    "see https://www.githubstatus.com",
+			width:    12,
+			expected: "see\nhttps://www.\ngithubstatus\n.com",
+		},
+		{
+			name:     "never splits a wide character",
+			input:    "日本語日本語",
+			width:    5,
+			expected: "日本\n語日\n本語",
+		},
+		{
+			name:     "keeps emoji sequences whole",
+			input:    "👩‍💻👩‍💻👩‍💻",
+			width:    4,
+			expected: "👩‍💻👩‍💻\n👩‍💻",
+		},
+		{
+			name:     "escape sequences take no space",
+			input:    "\x1b[1mBold\x1b[22m and \x1b]8;;https://github.com\x1b\\linked\x1b]8;;\x1b\\ text",
+			width:    15,
+			expected: "\x1b[1mBold\x1b[22m and \x1b]8;;https://github.com\x1b\\linked\x1b]8;;\x1b\\\ntext",
+		},
+		{
+			name:     "width of zero",
+			input:    "Text that is not wrapped",
+			width:    0,
+			expected: "Text that is not wrapped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := wrapText(tt.input, tt.width)
+			if result != tt.expected {
+				t.Errorf("wrapText(%q, %d) = %q, expected %q", tt.input, tt.width, result, tt.expected)
+			}
+			if tt.width == 0 {
+				return
+			}
+			for _, line := range strings.Split(result, "\n") {
+				if displayWidth(line) > tt.width {
+					t.Errorf("wrapText(%q, %d) produced line %q of width %d", tt.input, tt.width, line, displayWidth(line))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package status
+
+import "sync"
+
+// Cache stores the most recently retrieved summary along with its etag. Providing the same
+// Cache to multiple clients lets them share conditional requests, and an implementation
+// backed by a file lets the etag survive between runs.
+type Cache interface {
+	// Load returns the cached etag and summary, either of which may be empty
+	Load() (etag string, summary *SystemStatus)
+	// Store replaces the cached etag and summary
+	Store(etag string, summary *SystemStatus)
+}
+
+// MemoryCache is a Cache that keeps the summary in memory, it is safe for concurrent use
+type MemoryCache struct {
+	mu      sync.Mutex
+	etag    string
+	summary *SystemStatus
+}
+
+// NewMemoryCache returns an empty MemoryCache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{}
+}
+
+func (m *MemoryCache) Load() (string, *SystemStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.etag, m.summary
+}
+
+func (m *MemoryCache) Store(etag string, summary *SystemStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.etag = etag
+	m.summary = summary
+}
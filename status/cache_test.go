@@ -0,0 +1,20 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache()
+	etag, summary := cache.Load()
+	require.Empty(t, etag)
+	require.Nil(t, summary)
+
+	expected := &SystemStatus{}
+	cache.Store("foo", expected)
+	etag, summary = cache.Load()
+	require.Equal(t, "foo", etag)
+	require.Same(t, expected, summary)
+}
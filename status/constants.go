@@ -1,9 +1,30 @@
 package status
 
-const COMPONENT_OPERATIONAL = "operational"
-const COMPONENT_DEGREDADED_PERFORMANCE = "degraded_performance"
-const COMPONENT_PARTIAL_OUTAGE = "partial_outage"
-const COMPONENT_MAJOR_OUTAGE = "major_outage"
+// Component statuses reported by Statuspage, ordered by Severity
+const (
+	COMPONENT_OPERATIONAL            ComponentStatus = "operational"
+	COMPONENT_UNDER_MAINTENANCE      ComponentStatus = "under_maintenance"
+	COMPONENT_DEGREDADED_PERFORMANCE ComponentStatus = "degraded_performance"
+	COMPONENT_PARTIAL_OUTAGE         ComponentStatus = "partial_outage"
+	COMPONENT_MAJOR_OUTAGE           ComponentStatus = "major_outage"
+)
+
+// Incident statuses reported by Statuspage, in the order an incident usually moves through them
+const (
+	INCIDENT_INVESTIGATING IncidentStatus = "investigating"
+	INCIDENT_IDENTIFIED    IncidentStatus = "identified"
+	INCIDENT_MONITORING    IncidentStatus = "monitoring"
+	INCIDENT_RESOLVED      IncidentStatus = "resolved"
+	INCIDENT_POSTMORTEM    IncidentStatus = "postmortem"
+)
+
+// Overall status indicators reported by Statuspage, ordered by Severity
+const (
+	INDICATOR_NONE     Indicator = "none"
+	INDICATOR_MINOR    Indicator = "minor"
+	INDICATOR_MAJOR    Indicator = "major"
+	INDICATOR_CRITICAL Indicator = "critical"
+)
 
 // Version is used for the User-Agent header
 var Version = "dev"
@@ -7,6 +7,48 @@ import (
 )
 
 const (
+	testIncidentsResponse = `{
+  "page": {
+    "id": "kctbh9vrtdwd",
+    "name": "GitHub",
+    "url": "https://www.githubstatus.com",
+    "time_zone": "Etc/UTC",
+    "updated_at": "2024-01-16T07:59:13.397Z"
+  },
+  "incidents": [
+    {
+      "id": "abc123",
+      "name": "Disruption with some GitHub services",
+      "status": "resolved",
+      "impact": "minor",
+      "created_at": "2024-01-15T12:00:00.000Z",
+      "resolved_at": "2024-01-15T13:30:00.000Z",
+      "incident_updates": [
+        {
+          "id": "update2",
+          "status": "resolved",
+          "body": "This incident has been resolved.",
+          "created_at": "2024-01-15T13:30:00.000Z"
+        },
+        {
+          "id": "update1",
+          "status": "investigating",
+          "body": "We are investigating reports of degraded performance.",
+          "created_at": "2024-01-15T12:00:00.000Z"
+        }
+      ]
+    },
+    {
+      "id": "def456",
+      "name": "Incident with Actions",
+      "status": "investigating",
+      "impact": "major",
+      "created_at": "2024-01-16T07:00:00.000Z",
+      "resolved_at": null,
+      "incident_updates": []
+    }
+  ]
+}`
 	testJsonResponse = `{
   "page": {
     "id": "kctbh9vrtdwd",
@@ -15,6 +57,10 @@ const (
     "time_zone": "Etc/UTC",
     "updated_at": "2023-05-12T07:59:13.397Z"
   },
+  "status": {
+    "indicator": "minor",
+    "description": "Partially Degraded Service"
+  },
   "components": [
     {
       "id": "8l4ygp009s5s",
@@ -186,8 +232,18 @@ const (
 )
 
 func TestVerifyConstants(t *testing.T) {
-	require.Equal(t, "operational", COMPONENT_OPERATIONAL)
-	require.Equal(t, "degraded_performance", COMPONENT_DEGREDADED_PERFORMANCE)
-	require.Equal(t, "partial_outage", COMPONENT_PARTIAL_OUTAGE)
-	require.Equal(t, "major_outage", COMPONENT_MAJOR_OUTAGE)
+	require.Equal(t, ComponentStatus("operational"), COMPONENT_OPERATIONAL)
+	require.Equal(t, ComponentStatus("under_maintenance"), COMPONENT_UNDER_MAINTENANCE)
+	require.Equal(t, ComponentStatus("degraded_performance"), COMPONENT_DEGREDADED_PERFORMANCE)
+	require.Equal(t, ComponentStatus("partial_outage"), COMPONENT_PARTIAL_OUTAGE)
+	require.Equal(t, ComponentStatus("major_outage"), COMPONENT_MAJOR_OUTAGE)
+	require.Equal(t, IncidentStatus("investigating"), INCIDENT_INVESTIGATING)
+	require.Equal(t, IncidentStatus("identified"), INCIDENT_IDENTIFIED)
+	require.Equal(t, IncidentStatus("monitoring"), INCIDENT_MONITORING)
+	require.Equal(t, IncidentStatus("resolved"), INCIDENT_RESOLVED)
+	require.Equal(t, IncidentStatus("postmortem"), INCIDENT_POSTMORTEM)
+	require.Equal(t, Indicator("none"), INDICATOR_NONE)
+	require.Equal(t, Indicator("minor"), INDICATOR_MINOR)
+	require.Equal(t, Indicator("major"), INDICATOR_MAJOR)
+	require.Equal(t, Indicator("critical"), INDICATOR_CRITICAL)
 }
@@ -0,0 +1,74 @@
+package status
+
+// ChangeKind is the kind of difference found between two summaries
+type ChangeKind int
+
+const (
+	// ComponentChanged is a component whose status changed
+	ComponentChanged ChangeKind = iota
+	// IncidentCreated is an incident that wasn't in the previous summary
+	IncidentCreated
+	// IncidentUpdated is an incident with updates posted since the previous summary
+	IncidentUpdated
+	// IncidentResolved is an incident that has been resolved, or is no longer listed
+	IncidentResolved
+)
+
+// Change is a single difference between two summaries
+type Change struct {
+	Kind ChangeKind
+	// Component is the component as it is now, set for ComponentChanged
+	Component Components
+	// PreviousStatus is the status the component had before, set for ComponentChanged
+	PreviousStatus ComponentStatus
+	// Incident is the incident as it was last seen, set for every other kind
+	Incident Incidents
+}
+
+// Diff lists what changed between two summaries, components first in the order of current,
+// then incidents. Nothing has changed if either summary is nil. Components that were added or
+// removed are not reported.
+func Diff(previous, current *SystemStatus) []Change {
+	if previous == nil || current == nil {
+		return nil
+	}
+	var changes []Change
+
+	previousComponents := make(map[string]Components, len(previous.Components))
+	for _, component := range previous.Components {
+		previousComponents[component.ID] = component
+	}
+	for _, component := range current.Components {
+		before, ok := previousComponents[component.ID]
+		if ok && before.Status != component.Status {
+			changes = append(changes, Change{Kind: ComponentChanged, Component: component, PreviousStatus: before.Status})
+		}
+	}
+
+	previousIncidents := make(map[string]Incidents, len(previous.Incidents))
+	for _, incident := range previous.Incidents {
+		previousIncidents[incident.ID] = incident
+	}
+	currentIncidents := make(map[string]bool, len(current.Incidents))
+	for _, incident := range current.Incidents {
+		currentIncidents[incident.ID] = true
+		before, ok := previousIncidents[incident.ID]
+		switch {
+		case !ok && !incident.Status.Resolved():
+			changes = append(changes, Change{Kind: IncidentCreated, Incident: incident})
+		case !ok:
+			// Resolved before it was ever seen
+		case incident.Status.Resolved() && !before.Status.Resolved():
+			changes = append(changes, Change{Kind: IncidentResolved, Incident: incident})
+		case len(incident.IncidentUpdates) > len(before.IncidentUpdates):
+			changes = append(changes, Change{Kind: IncidentUpdated, Incident: incident})
+		}
+	}
+	// The summary only lists unresolved incidents, so those that disappear have been resolved
+	for _, incident := range previous.Incidents {
+		if !currentIncidents[incident.ID] && !incident.Status.Resolved() {
+			changes = append(changes, Change{Kind: IncidentResolved, Incident: incident})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,69 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_Nil(t *testing.T) {
+	summary := &SystemStatus{Components: []Components{{ID: "1", Status: COMPONENT_OPERATIONAL}}}
+	require.Empty(t, Diff(nil, summary))
+	require.Empty(t, Diff(summary, nil))
+	require.Empty(t, Diff(summary, summary))
+}
+
+func TestDiff_Components(t *testing.T) {
+	previous := &SystemStatus{
+		Components: []Components{
+			{ID: "1", Component: "Git Operations", Status: COMPONENT_OPERATIONAL},
+			{ID: "2", Component: "Actions", Status: COMPONENT_OPERATIONAL},
+			{ID: "3", Component: "Pages", Status: COMPONENT_OPERATIONAL},
+		},
+	}
+	current := &SystemStatus{
+		Components: []Components{
+			{ID: "1", Component: "Git Operations", Status: COMPONENT_OPERATIONAL},
+			{ID: "2", Component: "Actions", Status: COMPONENT_DEGREDADED_PERFORMANCE},
+			{ID: "4", Component: "Copilot", Status: COMPONENT_MAJOR_OUTAGE},
+		},
+	}
+
+	changes := Diff(previous, current)
+	require.Len(t, changes, 1)
+	require.Equal(t, ComponentChanged, changes[0].Kind)
+	require.Equal(t, "Actions", changes[0].Component.Component)
+	require.Equal(t, COMPONENT_DEGREDADED_PERFORMANCE, changes[0].Component.Status)
+	require.Equal(t, COMPONENT_OPERATIONAL, changes[0].PreviousStatus)
+}
+
+func TestDiff_Incidents(t *testing.T) {
+	previous := &SystemStatus{
+		Incidents: []Incidents{
+			{ID: "updated", Status: INCIDENT_INVESTIGATING, IncidentUpdates: []IncidentUpdate{{Status: INCIDENT_INVESTIGATING}}},
+			{ID: "resolved", Status: INCIDENT_MONITORING},
+			{ID: "removed", Status: INCIDENT_IDENTIFIED},
+			{ID: "unchanged", Status: INCIDENT_INVESTIGATING},
+		},
+	}
+	current := &SystemStatus{
+		Incidents: []Incidents{
+			{ID: "created", Status: INCIDENT_INVESTIGATING},
+			{ID: "updated", Status: INCIDENT_IDENTIFIED, IncidentUpdates: []IncidentUpdate{{Status: INCIDENT_IDENTIFIED}, {Status: INCIDENT_INVESTIGATING}}},
+			{ID: "resolved", Status: INCIDENT_RESOLVED},
+			{ID: "unchanged", Status: INCIDENT_INVESTIGATING},
+			{ID: "already resolved", Status: INCIDENT_RESOLVED},
+		},
+	}
+
+	changes := Diff(previous, current)
+	require.Len(t, changes, 4)
+	require.Equal(t, IncidentCreated, changes[0].Kind)
+	require.Equal(t, "created", changes[0].Incident.ID)
+	require.Equal(t, IncidentUpdated, changes[1].Kind)
+	require.Equal(t, "updated", changes[1].Incident.ID)
+	require.Equal(t, IncidentResolved, changes[2].Kind)
+	require.Equal(t, "resolved", changes[2].Incident.ID)
+	require.Equal(t, IncidentResolved, changes[3].Kind)
+	require.Equal(t, "removed", changes[3].Incident.ID)
+}
@@ -0,0 +1,39 @@
+// Package status retrieves the current status of GitHub from githubstatus.com, or any other
+// Statuspage instance, and can be embedded in other tools as a library.
+//
+// Checking the status once:
+//
+//	client := status.NewClient(status.WithTimeout(10 * time.Second))
+//	summary, _, err := client.Poll()
+//	if err != nil {
+//		return err
+//	}
+//	if summary.WorstStatus() != status.COMPONENT_OPERATIONAL {
+//		fmt.Println("GitHub is having problems")
+//	}
+//
+// Errors say what went wrong, so callers can decide whether to retry, back off or alert:
+//
+//	var serverErr status.ErrServerError
+//	switch {
+//	case errors.Is(err, status.ErrRateLimited):
+//		// poll less often
+//	case errors.As(err, &serverErr):
+//		fmt.Println("githubstatus.com responded with", serverErr.Code)
+//	case errors.Is(err, status.ErrCertificate):
+//		// trust the CA of an intercepting proxy with WithTLSConfig
+//	case errors.Is(err, status.ErrNetwork):
+//		// check the connection
+//	}
+//
+// Receiving a new Event every minute until ctx is cancelled:
+//
+//	for event := range client.Watch(ctx) {
+//		if event.Err != nil {
+//			continue
+//		}
+//		if actions, ok := event.Status.ComponentByName("Actions"); ok {
+//			fmt.Println(actions.Status)
+//		}
+//	}
+package status
@@ -0,0 +1,69 @@
+package status
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrRateLimited is returned when the status page responds with 429 Too Many Requests, polling
+// less often avoids it
+var ErrRateLimited = errors.New("rate limited by the status page")
+
+// ErrNetwork is wrapped around errors that stopped a response being received or read, such as
+// DNS failures, refused connections and timeouts. Check for it with errors.Is.
+var ErrNetwork = errors.New("unable to reach the status page")
+
+// ErrCertificate is wrapped around errors verifying the status page's TLS certificate, which
+// usually means a proxy is intercepting TLS with a CA that isn't trusted. Requests failing this
+// way are not retried. Check for it with errors.Is.
+var ErrCertificate = errors.New("unable to verify the status page's certificate")
+
+// ErrDecode is wrapped around errors decoding a response that isn't the JSON expected. Check
+// for it with errors.Is.
+var ErrDecode = errors.New("unable to decode the status page response")
+
+// ErrServerError is returned when the status page responds with an unexpected HTTP status code,
+// usually a 5xx while it is having problems of its own. Check for it with errors.As.
+type ErrServerError struct {
+	Code int // the HTTP status code received
+}
+
+func (e ErrServerError) Error() string {
+	if text := http.StatusText(e.Code); text != "" {
+		return fmt.Sprintf("unexpected http status code %d %s", e.Code, text)
+	}
+	return fmt.Sprintf("unexpected http status code %d", e.Code)
+}
+
+// statusCodeError classifies a response with an unexpected status code
+func statusCodeError(code int) error {
+	if code == http.StatusTooManyRequests {
+		return ErrRateLimited
+	}
+	return ErrServerError{Code: code}
+}
+
+// networkError marks err as a failure to receive or read a response
+func networkError(err error) error {
+	return fmt.Errorf("%w: %w", ErrNetwork, err)
+}
+
+// certificateError reports whether err is a failure to verify the server's certificate
+func certificateError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	return errors.As(err, &verificationErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &hostnameErr)
+}
+
+// decodeError marks err as a failure to decode a response
+func decodeError(err error) error {
+	return fmt.Errorf("%w: %w", ErrDecode, err)
+}
@@ -0,0 +1,89 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_PollShouldClassifyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name:    "rate limited",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTooManyRequests) },
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrRateLimited)
+			},
+		},
+		{
+			name:    "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+			check: func(t *testing.T, err error) {
+				var serverErr ErrServerError
+				require.ErrorAs(t, err, &serverErr)
+				require.Equal(t, http.StatusServiceUnavailable, serverErr.Code)
+				require.EqualError(t, err, "unexpected http status code 503 Service Unavailable")
+			},
+		},
+		{
+			name: "invalid json",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("<html>"))
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrDecode)
+				require.False(t, errors.Is(err, ErrNetwork))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(tt.handler)
+			defer svr.Close()
+			client := NewClient(WithBaseURL(svr.URL))
+
+			status, _, err := client.Poll()
+			require.Nil(t, status)
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestClient_PollShouldClassifyNetworkErrors(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	svr.Close()
+	client := NewClient(WithBaseURL(svr.URL))
+
+	_, _, err := client.Poll()
+	require.ErrorIs(t, err, ErrNetwork)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = client.PollContext(ctx)
+	require.ErrorIs(t, err, ErrNetwork)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClient_PollShouldClassifyCertificateErrors(t *testing.T) {
+	svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(testJsonResponse))
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithRetries(2), WithRetryBackoff(time.Millisecond))
+
+	result, err := client.PollWithResult(context.Background())
+	require.ErrorIs(t, err, ErrCertificate)
+	require.False(t, errors.Is(err, ErrNetwork))
+	require.Equal(t, 1, result.Attempts)
+}
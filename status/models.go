@@ -1,52 +1,154 @@
 package status
 
 import (
-	"encoding/json"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 )
 
+// SystemStatus is the summary of a Statuspage, containing every component and unresolved incident
 type SystemStatus struct {
+	Status     PageStatus   `json:"status"`
 	Components []Components `json:"components"`
 	Incidents  []Incidents  `json:"incidents"`
 }
 
+// PageStatus is Statuspage's own summary of the whole page, such as "All Systems Operational"
+type PageStatus struct {
+	Indicator   Indicator `json:"indicator"`
+	Description string    `json:"description"`
+}
+
+// Components is a single component of the page such as "Actions" or "Git Operations"
 type Components struct {
 	ID        string          `json:"id"`
 	Component string          `json:"name"`
 	Status    ComponentStatus `json:"status"`
 }
 
+// Incidents is an incident along with every update that has been posted to it, newest first
 type Incidents struct {
 	Status          IncidentStatus   `json:"status"`
 	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Impact          string           `json:"impact"`
+	CreatedAt       *Time            `json:"created_at,omitempty"`
+	ResolvedAt      *Time            `json:"resolved_at,omitempty"`
 	IncidentUpdates []IncidentUpdate `json:"incident_updates"`
 }
 
+// IncidentUpdate is a single message posted to an incident
 type IncidentUpdate struct {
+	ID        string         `json:"id,omitempty"`
 	Status    IncidentStatus `json:"status"`
 	Update    string         `json:"body"`
 	Timestamp *Time          `json:"created_at"`
 }
 
+// ComponentStatus is the health of a component, see the COMPONENT_ constants
 type ComponentStatus string
+
+// IncidentStatus is the progress of an incident, see the INCIDENT_ constants
 type IncidentStatus string
 
-var brTagRegex = regexp.MustCompile(`<br\s*/?>`)
+// Indicator is the overall health of the page, see the INDICATOR_ constants
+type Indicator string
+
+// Severity orders component statuses from operational (0) to major outage (4), statuses that
+// are not known to this package have a severity of -1 so they never outrank a known one.
+func (s ComponentStatus) Severity() int {
+	switch s {
+	case COMPONENT_OPERATIONAL:
+		return 0
+	case COMPONENT_UNDER_MAINTENANCE:
+		return 1
+	case COMPONENT_DEGREDADED_PERFORMANCE:
+		return 2
+	case COMPONENT_PARTIAL_OUTAGE:
+		return 3
+	case COMPONENT_MAJOR_OUTAGE:
+		return 4
+	default:
+		return -1
+	}
+}
+
+// Severity orders indicators from none (0) to critical (3), indicators that are not known to
+// this package have a severity of -1 so they never outrank a known one.
+func (i Indicator) Severity() int {
+	switch i {
+	case INDICATOR_NONE:
+		return 0
+	case INDICATOR_MINOR:
+		return 1
+	case INDICATOR_MAJOR:
+		return 2
+	case INDICATOR_CRITICAL:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// Resolved reports whether the incident no longer needs attention
+func (s IncidentStatus) Resolved() bool {
+	return s == INCIDENT_RESOLVED || s == INCIDENT_POSTMORTEM
+}
 
-func (iu *IncidentUpdate) UnmarshalJSON(b []byte) error {
-	type Alias IncidentUpdate
-	var alias Alias
-	if err := json.Unmarshal(b, &alias); err != nil {
-		return err
+// WorstStatus returns the most severe status of any component, or COMPONENT_OPERATIONAL
+// if there are no components
+func (s *SystemStatus) WorstStatus() ComponentStatus {
+	worst := COMPONENT_OPERATIONAL
+	for _, component := range s.Components {
+		if component.Status.Severity() > worst.Severity() {
+			worst = component.Status
+		}
 	}
-	alias.Update = brTagRegex.ReplaceAllString(alias.Update, " ")
-	*iu = IncidentUpdate(alias)
-	return nil
+	return worst
 }
 
+// Overall returns the overall status published by Statuspage. Summaries without one, such as
+// those built by hand, fall back to a status based on the worst component status.
+func (s *SystemStatus) Overall() PageStatus {
+	if s.Status.Indicator != "" {
+		return s.Status
+	}
+	switch s.WorstStatus() {
+	case COMPONENT_UNDER_MAINTENANCE:
+		return PageStatus{Indicator: INDICATOR_NONE, Description: "Service Under Maintenance"}
+	case COMPONENT_DEGREDADED_PERFORMANCE:
+		return PageStatus{Indicator: INDICATOR_MINOR, Description: "Minor Service Outage"}
+	case COMPONENT_PARTIAL_OUTAGE:
+		return PageStatus{Indicator: INDICATOR_MINOR, Description: "Partial System Outage"}
+	case COMPONENT_MAJOR_OUTAGE:
+		return PageStatus{Indicator: INDICATOR_MAJOR, Description: "Major System Outage"}
+	default:
+		return PageStatus{Indicator: INDICATOR_NONE, Description: "All Systems Operational"}
+	}
+}
+
+// ComponentByName finds a component by its case-insensitive name
+func (s *SystemStatus) ComponentByName(name string) (*Components, bool) {
+	for i := range s.Components {
+		if strings.EqualFold(s.Components[i].Component, name) {
+			return &s.Components[i], true
+		}
+	}
+	return nil, false
+}
+
+// ActiveIncidents returns the incidents which have not been resolved yet
+func (s *SystemStatus) ActiveIncidents() []Incidents {
+	active := make([]Incidents, 0, len(s.Incidents))
+	for _, incident := range s.Incidents {
+		if !incident.Status.Resolved() {
+			active = append(active, incident)
+		}
+	}
+	return active
+}
+
+// Time is a timestamp as formatted by Statuspage, it is nil when Statuspage sends null
 type Time struct {
 	*time.Time
 }
@@ -58,6 +160,16 @@ func (t *Time) String() string {
 	return t.Time.Format("2006-01-02 3:04 PM")
 }
 
+// timeLayouts are the timestamp formats accepted from Statuspage instances, tried in order.
+// Fractional seconds are optional for all of them.
+var timeLayouts = []string{
+	time.RFC3339,                // 2006-01-02T15:04:05.999Z or with an offset such as +00:00
+	"2006-01-02T15:04:05Z0700",  // offset without a colon such as +0000
+	"2006-01-02T15:04:05",       // no time zone, assumed to be UTC
+	"2006-01-02 15:04:05Z07:00", // space instead of T
+	time.DateOnly,               // dates such as a component's start_date
+}
+
 func (t *Time) UnmarshalJSON(b []byte) error {
 	timeAsString := string(b)
 	timeAsString = strings.Trim(timeAsString, "\"")
@@ -65,17 +177,24 @@ func (t *Time) UnmarshalJSON(b []byte) error {
 		t.Time = nil
 		return nil
 	}
-	tim, err := time.Parse("2006-01-02T15:04:05.999Z", timeAsString)
-	if err != nil {
-		return err
+	var firstErr error
+	for _, layout := range timeLayouts {
+		tim, err := time.Parse(layout, timeAsString)
+		if err == nil {
+			t.Time = &tim
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
 	}
-	t.Time = &tim
-	return nil
+	return firstErr
 }
 
+// MarshalJSON writes the time in RFC 3339 so it can be decoded again by UnmarshalJSON
 func (t *Time) MarshalJSON() ([]byte, error) {
 	if t.Time == nil {
 		return []byte("null"), nil
 	}
-	return []byte(fmt.Sprintf("%q", t.String())), nil
+	return []byte(fmt.Sprintf("%q", t.Time.Format(time.RFC3339Nano))), nil
 }
@@ -1,6 +1,7 @@
 package status
 
 import (
+	"encoding/json"
 	"testing"
 	datetime "time"
 
@@ -44,64 +45,168 @@ func TestTime_MarshalJSON_Time(t *testing.T) {
 	require.NoError(t, err)
 	timeString, err := time.MarshalJSON()
 	require.NoError(t, err)
-	require.Equal(t, "\"2014-05-03 1:22 AM\"", string(timeString))
+	require.Equal(t, "\"2014-05-03T01:22:07.286Z\"", string(timeString))
 }
 
-func TestTime_MarshalJSON_Null(t *testing.T) {
-	time := new(Time)
-	timeString, err := time.MarshalJSON()
-	require.NoError(t, err)
-	require.Equal(t, "null", string(timeString))
-}
-func TestIncidentUpdate_UnmarshalJSON_StripsBrTags(t *testing.T) {
+func TestTime_UnmarshalJSON_Variants(t *testing.T) {
+	expected := datetime.Date(2014, datetime.May, 3, 1, 22, 7, 0, datetime.UTC)
 	tests := []struct {
 		name     string
-		json     string
-		expected string
+		rawTime  string
+		expected datetime.Time
 	}{
-		{
-			name:     "br with space and slash",
-			json:     `{"body":"some text<br />more text","status":"investigating","created_at":"2014-05-03T01:22:07.286Z"}`,
-			expected: "some text more text",
-		},
-		{
-			name:     "self-closing br",
-			json:     `{"body":"some text<br/>more text","status":"investigating","created_at":"2014-05-03T01:22:07.286Z"}`,
-			expected: "some text more text",
-		},
-		{
-			name:     "bare br",
-			json:     `{"body":"some text<br>more text","status":"investigating","created_at":"2014-05-03T01:22:07.286Z"}`,
-			expected: "some text more text",
-		},
-		{
-			name:     "multiple br tags",
-			json:     `{"body":"first<br />second<br/>third<br>fourth","status":"investigating","created_at":"2014-05-03T01:22:07.286Z"}`,
-			expected: "first second third fourth",
-		},
-		{
-			name:     "no br tags",
-			json:     `{"body":"just plain text","status":"investigating","created_at":"2014-05-03T01:22:07.286Z"}`,
-			expected: "just plain text",
-		},
-		{
-			name:     "trailing br tag",
-			json:     `{"body":"message text<br />","status":"investigating","created_at":"2014-05-03T01:22:07.286Z"}`,
-			expected: "message text ",
-		},
+		{name: "zulu without fractional seconds", rawTime: "\"2014-05-03T01:22:07Z\"", expected: expected},
+		{name: "zulu with fractional seconds", rawTime: "\"2014-05-03T01:22:07.286Z\"", expected: expected.Add(286 * datetime.Millisecond)},
+		{name: "utc offset", rawTime: "\"2014-05-03T01:22:07+00:00\"", expected: expected},
+		{name: "negative offset", rawTime: "\"2014-05-02T21:22:07.000-04:00\"", expected: expected},
+		{name: "offset without colon", rawTime: "\"2014-05-03T03:22:07+0200\"", expected: expected},
+		{name: "no time zone", rawTime: "\"2014-05-03T01:22:07\"", expected: expected},
+		{name: "space separator", rawTime: "\"2014-05-03 01:22:07Z\"", expected: expected},
+		{name: "date only", rawTime: "\"2014-05-03\"", expected: datetime.Date(2014, datetime.May, 3, 0, 0, 0, 0, datetime.UTC)},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var update IncidentUpdate
-			err := update.UnmarshalJSON([]byte(tt.json))
+			time := new(Time)
+			err := time.UnmarshalJSON([]byte(tt.rawTime))
 			require.NoError(t, err)
-			require.Equal(t, tt.expected, update.Update)
+			require.True(t, tt.expected.Equal(*time.Time), "expected %s, got %s", tt.expected, time.Time)
 		})
 	}
 }
 
+func TestTime_MarshalJSON_RoundTrip(t *testing.T) {
+	rawTime := "\"2014-05-02T21:22:07.123456-04:00\""
+	original := new(Time)
+	require.NoError(t, original.UnmarshalJSON([]byte(rawTime)))
+
+	marshalled, err := original.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, rawTime, string(marshalled))
+
+	decoded := new(Time)
+	require.NoError(t, decoded.UnmarshalJSON(marshalled))
+	require.True(t, original.Equal(*decoded.Time))
+}
+
+func TestSystemStatus_JSONRoundTrip(t *testing.T) {
+	original := new(SystemStatus)
+	require.NoError(t, json.Unmarshal([]byte(testIncidentsResponse), original))
+
+	exported, err := json.Marshal(original)
+	require.NoError(t, err)
+	reimported := new(SystemStatus)
+	require.NoError(t, json.Unmarshal(exported, reimported))
+	require.True(t, original.Incidents[0].CreatedAt.Equal(*reimported.Incidents[0].CreatedAt.Time))
+	require.Equal(t, original.Incidents[0].IncidentUpdates[0].Update, reimported.Incidents[0].IncidentUpdates[0].Update)
+}
+
+func TestTime_MarshalJSON_Null(t *testing.T) {
+	time := new(Time)
+	timeString, err := time.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, "null", string(timeString))
+}
+func TestIncidentUpdate_UnmarshalJSON_KeepsRawBody(t *testing.T) {
+	var update IncidentUpdate
+	err := json.Unmarshal([]byte(`{"body":"first<br />second<br>third","status":"investigating","created_at":"2014-05-03T01:22:07.286Z"}`), &update)
+	require.NoError(t, err)
+	require.Equal(t, "first<br />second<br>third", update.Update)
+}
+
 func TestTime_String_Null(t *testing.T) {
 	time := new(Time)
 	require.Equal(t, "", time.String())
 }
+
+func TestComponentStatus_Severity(t *testing.T) {
+	ordered := []ComponentStatus{
+		"unknown",
+		COMPONENT_OPERATIONAL,
+		COMPONENT_UNDER_MAINTENANCE,
+		COMPONENT_DEGREDADED_PERFORMANCE,
+		COMPONENT_PARTIAL_OUTAGE,
+		COMPONENT_MAJOR_OUTAGE,
+	}
+	for i := 1; i < len(ordered); i++ {
+		require.Less(t, ordered[i-1].Severity(), ordered[i].Severity())
+	}
+}
+
+func TestIndicator_Severity(t *testing.T) {
+	ordered := []Indicator{
+		"unknown",
+		INDICATOR_NONE,
+		INDICATOR_MINOR,
+		INDICATOR_MAJOR,
+		INDICATOR_CRITICAL,
+	}
+	for i := 1; i < len(ordered); i++ {
+		require.Less(t, ordered[i-1].Severity(), ordered[i].Severity())
+	}
+}
+
+func TestSystemStatus_Overall(t *testing.T) {
+	summary := &SystemStatus{
+		Status: PageStatus{Indicator: INDICATOR_CRITICAL, Description: "Major System Outage"},
+		Components: []Components{
+			{Component: "Git Operations", Status: COMPONENT_OPERATIONAL},
+		},
+	}
+	require.Equal(t, summary.Status, summary.Overall())
+}
+
+func TestSystemStatus_OverallWithoutIndicator(t *testing.T) {
+	summary := &SystemStatus{}
+	require.Equal(t, PageStatus{Indicator: INDICATOR_NONE, Description: "All Systems Operational"}, summary.Overall())
+
+	summary.Components = []Components{
+		{Component: "Git Operations", Status: COMPONENT_OPERATIONAL},
+		{Component: "Actions", Status: COMPONENT_MAJOR_OUTAGE},
+	}
+	require.Equal(t, PageStatus{Indicator: INDICATOR_MAJOR, Description: "Major System Outage"}, summary.Overall())
+}
+
+func TestSystemStatus_WorstStatus(t *testing.T) {
+	summary := &SystemStatus{}
+	require.Equal(t, COMPONENT_OPERATIONAL, summary.WorstStatus())
+
+	summary.Components = []Components{
+		{Component: "Git Operations", Status: COMPONENT_OPERATIONAL},
+		{Component: "Actions", Status: COMPONENT_PARTIAL_OUTAGE},
+		{Component: "Pages", Status: COMPONENT_DEGREDADED_PERFORMANCE},
+		{Component: "Copilot", Status: "unknown"},
+	}
+	require.Equal(t, COMPONENT_PARTIAL_OUTAGE, summary.WorstStatus())
+}
+
+func TestSystemStatus_ComponentByName(t *testing.T) {
+	summary := &SystemStatus{
+		Components: []Components{
+			{ID: "1", Component: "Git Operations", Status: COMPONENT_OPERATIONAL},
+			{ID: "2", Component: "Actions", Status: COMPONENT_MAJOR_OUTAGE},
+		},
+	}
+	component, ok := summary.ComponentByName("actions")
+	require.True(t, ok)
+	require.Equal(t, "2", component.ID)
+
+	component, ok = summary.ComponentByName("Codespaces")
+	require.False(t, ok)
+	require.Nil(t, component)
+}
+
+func TestSystemStatus_ActiveIncidents(t *testing.T) {
+	summary := &SystemStatus{
+		Incidents: []Incidents{
+			{ID: "1", Status: INCIDENT_INVESTIGATING},
+			{ID: "2", Status: INCIDENT_RESOLVED},
+			{ID: "3", Status: INCIDENT_MONITORING},
+			{ID: "4", Status: INCIDENT_POSTMORTEM},
+		},
+	}
+	active := summary.ActiveIncidents()
+	require.Len(t, active, 2)
+	require.Equal(t, "1", active[0].ID)
+	require.Equal(t, "3", active[1].ID)
+}
@@ -0,0 +1,151 @@
+package status
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures optional behavior of a Client
+type Option func(*Client)
+
+// WithBaseURL points the client at another Statuspage instance, for example
+// "https://www.githubstatus.com" which is the default
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with each request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithCache stores the etag and last summary in the provided Cache instead of in memory
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithLogger logs the requests made by the client, by default nothing is logged
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithHTTPClient uses the provided http.Client to make requests instead of http.DefaultClient,
+// a nil client keeps http.DefaultClient
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		c.client = client
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to make requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithTimeout limits how long a single request, including reading the response body, may take.
+// A timeout of zero means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = &timeout
+	}
+}
+
+// WithProxy sends requests through the given proxy. It only applies when the transport in use
+// is an *http.Transport, which is the case unless WithTransport or WithHTTPClient say otherwise.
+func WithProxy(proxy *url.URL) Option {
+	return func(c *Client) {
+		c.proxy = http.ProxyURL(proxy)
+	}
+}
+
+// WithTLSConfig uses the given TLS configuration for requests, for example to trust the CA of a
+// proxy that intercepts TLS or to present a client certificate. Like WithProxy it only applies
+// when the transport in use is an *http.Transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = config
+	}
+}
+
+// WithRetries retries a request up to the given number of additional times when it fails in a
+// way that may be temporary: it timed out, the connection was refused or reset, or the server
+// responded with a 5xx or 429 status code. By default requests are not retried.
+func WithRetries(retries int) Option {
+	return func(c *Client) {
+		c.retries = retries
+	}
+}
+
+// WithRetryBackoff sets how long to wait before the first retry, which doubles for each retry
+// after that up to 30 seconds. Each wait is jittered, and a Retry-After header from the server
+// takes precedence, also up to 30 seconds. The default is one second.
+func WithRetryBackoff(backoff time.Duration) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+// WithRetryTimeLimit stops retrying a request once the given time has passed since the first
+// attempt, including the time spent waiting between attempts. The default is one minute, a limit
+// of zero means no limit.
+func WithRetryTimeLimit(limit time.Duration) Option {
+	return func(c *Client) {
+		c.retryTime = limit
+	}
+}
+
+// WithPollInterval sets how often Watch polls for a new status
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.interval = interval
+	}
+}
+
+// buildHTTPClient applies the transport related options on top of a copy of the configured
+// http.Client, so a client passed in with WithHTTPClient is never modified.
+func (c *Client) buildHTTPClient() {
+	if c.transport == nil && c.timeout == nil && c.proxy == nil && c.tlsConfig == nil {
+		return
+	}
+	httpClient := *c.client
+	if c.transport != nil {
+		httpClient.Transport = c.transport
+	}
+	if c.timeout != nil {
+		httpClient.Timeout = *c.timeout
+	}
+	if c.proxy != nil || c.tlsConfig != nil {
+		transport := httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		if t, ok := transport.(*http.Transport); ok {
+			t = t.Clone()
+			if c.proxy != nil {
+				t.Proxy = c.proxy
+			}
+			if c.tlsConfig != nil {
+				t.TLSClientConfig = c.tlsConfig
+			}
+			httpClient.Transport = t
+		}
+	}
+	c.client = &httpClient
+}
@@ -0,0 +1,195 @@
+package status
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHTTPClient(t *testing.T) {
+	httpClient := &http.Client{}
+	client := NewClient(WithHTTPClient(httpClient))
+	require.Same(t, httpClient, client.client)
+}
+
+func TestWithHTTPClient_Nil(t *testing.T) {
+	client := NewClient(WithHTTPClient(nil))
+	require.Same(t, http.DefaultClient, client.client)
+
+	client = NewClient(WithHTTPClient(nil), WithTimeout(time.Second))
+	require.Equal(t, time.Second, client.client.Timeout)
+	require.Zero(t, http.DefaultClient.Timeout)
+}
+
+func TestWithTimeoutDoesNotModifyProvidedClient(t *testing.T) {
+	client := NewClient(WithTimeout(5 * time.Second))
+	require.NotSame(t, http.DefaultClient, client.client)
+	require.Equal(t, 5*time.Second, client.client.Timeout)
+	require.Zero(t, http.DefaultClient.Timeout)
+}
+
+func TestWithTransport(t *testing.T) {
+	transport := &http.Transport{}
+	client := NewClient(WithTransport(transport))
+	require.Same(t, transport, client.client.Transport)
+}
+
+func TestWithProxy(t *testing.T) {
+	proxy, err := url.Parse("http://proxy.example.com:3128")
+	require.NoError(t, err)
+	client := NewClient(WithProxy(proxy))
+
+	transport, ok := client.client.Transport.(*http.Transport)
+	require.True(t, ok)
+	req := httptest.NewRequest(http.MethodGet, "https://www.githubstatus.com", nil)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	require.Equal(t, proxy, proxyURL)
+	require.NotSame(t, http.DefaultTransport, client.client.Transport)
+}
+
+func TestWithRetries(t *testing.T) {
+	retryDelay = time.Millisecond
+	defer func() { retryDelay = time.Second }()
+
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			// Drop the connection without responding
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testJsonResponse))
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithRetries(2))
+
+	status, _, err := client.Poll()
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	require.Equal(t, 3, requests)
+}
+
+func TestWithRetries_RetriesServerErrors(t *testing.T) {
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(200)
+			_, err := w.Write([]byte(testJsonResponse))
+			require.NoError(t, err)
+		}
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithRetries(3), WithRetryBackoff(time.Millisecond))
+
+	result, err := client.PollWithResult(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, result.Summary)
+	require.Equal(t, 3, result.Attempts)
+	require.Equal(t, 3, requests)
+}
+
+func TestWithRetries_DoesNotRetryClientErrors(t *testing.T) {
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithRetries(3), WithRetryBackoff(time.Millisecond))
+
+	_, _, err := client.Poll()
+	var serverErr ErrServerError
+	require.ErrorAs(t, err, &serverErr)
+	require.Equal(t, http.StatusNotFound, serverErr.Code)
+	require.Equal(t, 1, requests)
+}
+
+func TestWithRetryTimeLimit(t *testing.T) {
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithRetries(5), WithRetryBackoff(time.Hour), WithRetryTimeLimit(time.Second))
+
+	_, _, err := client.Poll()
+	var serverErr ErrServerError
+	require.ErrorAs(t, err, &serverErr)
+	require.Equal(t, http.StatusBadGateway, serverErr.Code)
+	require.Equal(t, 1, requests)
+}
+
+func TestClient_RetryWait(t *testing.T) {
+	client := NewClient(WithRetryBackoff(time.Second))
+	for attempt, backoff := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: maxRetryDelay} {
+		for range 20 {
+			wait := client.retryWait(attempt, nil)
+			require.GreaterOrEqual(t, wait, backoff/2)
+			require.LessOrEqual(t, wait, backoff)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	require.Equal(t, 7*time.Second, client.retryWait(1, resp))
+	resp.Header.Set("Retry-After", "3600")
+	require.Equal(t, maxRetryDelay, client.retryWait(1, resp))
+}
+
+func TestWithRetries_RetriesRefusedConnections(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithRetries(2), WithRetryBackoff(time.Millisecond))
+
+	result, err := client.PollWithResult(context.Background())
+	require.ErrorIs(t, err, ErrNetwork)
+	require.Equal(t, 3, result.Attempts)
+}
+
+func TestWithRetries_DoesNotRetryPermanentFailures(t *testing.T) {
+	client := NewClient(WithBaseURL("ftp://www.githubstatus.com"), WithRetries(3), WithRetryBackoff(time.Hour))
+
+	result, err := client.PollWithResult(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 1, result.Attempts)
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(testJsonResponse))
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(svr.Certificate())
+	client := NewClient(WithBaseURL(svr.URL), WithTLSConfig(&tls.Config{RootCAs: roots}))
+
+	status, _, err := client.Poll()
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	require.NotSame(t, http.DefaultTransport, client.client.Transport)
+	if defaultConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig; defaultConfig != nil {
+		require.Nil(t, defaultConfig.RootCAs)
+	}
+}
@@ -2,67 +2,266 @@ package status
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 )
 
+// DefaultBaseURL is the Statuspage instance for github.com
+const DefaultBaseURL = "https://www.githubstatus.com"
+
+// retryDelay is how long to wait before the first retry when a request fails, doubling for each
+// retry after that, unless WithRetryBackoff says otherwise
+var retryDelay = time.Second
+
+// maxRetryDelay is the longest wait between two attempts at a request
+const maxRetryDelay = 30 * time.Second
+
+// defaultRetryTimeLimit is how long a request keeps being retried, unless WithRetryTimeLimit says
+// otherwise
+const defaultRetryTimeLimit = time.Minute
+
 type Client struct {
-	etag   *string // etag to reduce API bandwidth usage
-	client *http.Client
-	apiURL string
+	cache     Cache // etag and last successfully decoded summary, to reduce API bandwidth usage
+	client    *http.Client
+	baseURL   string
+	userAgent string
+	logger    *slog.Logger
+	retries   int
+	backoff   time.Duration // wait before the first retry, doubling for each retry after that
+	retryTime time.Duration // how long retrying may go on for, zero for no limit
+	interval  time.Duration
+	timeout   *time.Duration
+	transport http.RoundTripper
+	proxy     func(*http.Request) (*url.URL, error)
+	tlsConfig *tls.Config
 }
 
-func NewClient() *Client {
-	return &Client{
-		etag:   nil,
-		client: http.DefaultClient,
-		apiURL: "https://www.githubstatus.com/api/v2/summary.json",
+// NewClient creates a Client for githubstatus.com, which can be customized with options
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		cache:     NewMemoryCache(),
+		client:    http.DefaultClient,
+		baseURL:   DefaultBaseURL,
+		userAgent: fmt.Sprintf("gh-status/%s", strings.TrimLeft(Version, "v")),
+		logger:    slog.New(slog.DiscardHandler),
+		backoff:   retryDelay,
+		retryTime: defaultRetryTimeLimit,
+		interval:  DefaultPollInterval,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.buildHTTPClient()
+	return c
+}
+
+// Poll retrieves the current status of GitHub, see PollContext for details.
+func (c *Client) Poll() (summary *SystemStatus, notModified bool, err error) {
+	return c.PollContext(context.Background())
 }
 
-func (c *Client) Poll() (*SystemStatus, error) {
-	resp, err := c.getData()
+// PollContext retrieves the current status of GitHub, aborting the request if ctx is cancelled.
+// If nothing has changed since the last poll the last known good summary is returned with
+// notModified set to true, so callers always receive data to render without needing to keep
+// their own copy. Errors can be classified with ErrRateLimited, ErrServerError, ErrNetwork,
+// ErrCertificate and ErrDecode.
+func (c *Client) PollContext(ctx context.Context) (summary *SystemStatus, notModified bool, err error) {
+	result, err := c.PollWithResult(ctx)
+	return result.Summary, result.NotModified, err
+}
+
+// PollResult is the outcome of a poll along with details of the response
+type PollResult struct {
+	Summary     *SystemStatus // the current summary, nil if the poll failed
+	NotModified bool          // true if nothing changed since the previous poll
+	StatusCode  int           // HTTP status code of the last response, 0 if none was received
+	Latency     time.Duration // how long the last request took to respond
+	Attempts    int           // how many requests were made, including retries
+}
+
+// PollWithResult works like PollContext but also describes the response, such as whether it
+// was a 200 or a 304 and how long it took. The details are filled in as far as the poll got
+// even when an error is returned.
+func (c *Client) PollWithResult(ctx context.Context) (PollResult, error) {
+	etag, last := c.cache.Load()
+	if last == nil {
+		// Without a summary to fall back on a 304 is of no use, so don't ask for one
+		etag = ""
+	}
+	resp, info, err := c.getData(ctx, c.summaryURL(), etag)
+	result := PollResult{Latency: info.latency, Attempts: info.attempts}
 	if err != nil {
-		return nil, err
+		return result, err
 	}
+	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
 	switch resp.StatusCode {
 	case http.StatusOK:
-		newEtag := resp.Header.Get("etag")
-		c.etag = &newEtag
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, err
+			return result, networkError(err)
 		}
-		result := new(SystemStatus)
-		err = json.Unmarshal(body, result)
+		summary := new(SystemStatus)
+		err = json.Unmarshal(body, summary)
 		if err != nil {
-			return nil, err
+			return result, decodeError(err)
 		}
+		c.cache.Store(resp.Header.Get("etag"), summary)
+		result.Summary = summary
 		return result, nil
 	case http.StatusNotModified:
-		// No updates, just return nil
-		return nil, nil
+		if etag == "" {
+			return result, fmt.Errorf("%w for a request without an etag", ErrServerError{Code: resp.StatusCode})
+		}
+		result.Summary = last
+		result.NotModified = true
+		return result, nil
 	default:
-		return nil, fmt.Errorf("unexpected http status code, expected 200 or 304, but got %d", resp.StatusCode)
+		return result, statusCodeError(resp.StatusCode)
+	}
+}
+
+// IncidentHistory retrieves the most recent incidents, including resolved ones, newest first
+func (c *Client) IncidentHistory(ctx context.Context) ([]Incidents, error) {
+	resp, _, err := c.getData(ctx, c.incidentsURL(), "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusCodeError(resp.StatusCode)
 	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, networkError(err)
+	}
+	result := new(SystemStatus)
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	return result.Incidents, nil
+}
+
+// summaryURL is the endpoint for the summary of components and unresolved incidents
+func (c *Client) summaryURL() string {
+	return c.baseURL + "/api/v2/summary.json"
 }
 
-func (c *Client) getData() (*http.Response, error) {
+// incidentsURL is the endpoint for the 50 most recent incidents
+func (c *Client) incidentsURL() string {
+	return c.baseURL + "/api/v2/incidents.json"
+}
+
+// requestInfo describes the requests made by a single call to getData
+type requestInfo struct {
+	latency  time.Duration // how long the last request took to respond
+	attempts int           // how many requests were made, including retries
+}
+
+// getData requests url, retrying requests that fail in ways that may be temporary as configured
+// by WithRetries, WithRetryBackoff and WithRetryTimeLimit. Once retrying stops the last response
+// or error is returned.
+func (c *Client) getData(ctx context.Context, url string, etag string) (*http.Response, requestInfo, error) {
+	var info requestInfo
+	start := time.Now()
+	for {
+		info.attempts++
+		requestStart := time.Now()
+		resp, err := c.doRequest(ctx, url, etag)
+		info.latency = time.Since(requestStart)
+		if info.attempts > c.retries || ctx.Err() != nil || !retryable(resp, err) {
+			return resp, info, err
+		}
+		wait := c.retryWait(info.attempts, resp)
+		if c.retryTime > 0 && time.Since(start)+wait > c.retryTime {
+			return resp, info, err
+		}
+
+		if err != nil {
+			c.logger.Debug("retrying request", "attempt", info.attempts, "wait", wait, "error", err)
+		} else {
+			c.logger.Debug("retrying request", "attempt", info.attempts, "wait", wait, "status", resp.StatusCode)
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, info, networkError(ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryable reports whether a request may succeed if it is tried again: it timed out, the
+// connection was refused or dropped, or the server is overloaded or having problems. Failures
+// that won't go away by themselves, such as a bad URL or a host that doesn't exist, aren't.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			// A connection closed before any response arrived is reported as a bare EOF
+			errors.Is(err, io.EOF)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryWait is how long to wait before retrying after the given attempt. The backoff doubles with
+// each attempt and is jittered so many clients don't retry in lockstep, unless the server said
+// how long to wait with a Retry-After header. Either way the wait is at most maxRetryDelay.
+func (c *Client) retryWait(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxRetryDelay)
+		}
+	}
+	wait := c.backoff
+	for i := 1; i < attempt && wait < maxRetryDelay; i++ {
+		wait *= 2
+	}
+	wait = min(wait, maxRetryDelay)
+	if wait <= 0 {
+		return 0
+	}
+	// Wait somewhere between half and all of the backoff
+	return wait/2 + rand.N(wait/2+1)
+}
+
+func (c *Client) doRequest(ctx context.Context, url string, etag string) (*http.Response, error) {
 	reader := strings.Reader{}
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, c.apiURL, &reader)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, &reader)
 	if err != nil {
 		return nil, err
 	}
-	if c.etag != nil {
-		req.Header.Add("If-None-Match", *c.etag)
+	if etag != "" {
+		req.Header.Add("If-None-Match", etag)
 	}
-	req.Header.Add("User-Agent", fmt.Sprintf("gh-status/%s", strings.TrimLeft(Version, "v")))
+	req.Header.Add("User-Agent", c.userAgent)
+	c.logger.Debug("requesting status", "url", req.URL.String(), "etag", etag)
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		c.logger.Debug("request failed", "error", err)
+		if certificateError(err) {
+			return nil, fmt.Errorf("%w: %w", ErrCertificate, err)
+		}
+		return nil, networkError(err)
 	}
+	c.logger.Debug("received response", "status", resp.StatusCode)
 	return resp, nil
 }
@@ -1,6 +1,9 @@
 package status
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,8 +14,11 @@ import (
 func TestNewClientReturnsClient(t *testing.T) {
 	client := NewClient()
 	require.NotNil(t, client)
-	require.Nil(t, client.etag)
+	etag, summary := client.cache.Load()
+	require.Empty(t, etag)
+	require.Nil(t, summary)
 	require.Equal(t, http.DefaultClient, client.client)
+	require.Equal(t, "https://www.githubstatus.com/api/v2/summary.json", client.summaryURL())
 }
 
 func TestClient_GetDataDoesNotPassInEtag(t *testing.T) {
@@ -21,10 +27,9 @@ func TestClient_GetDataDoesNotPassInEtag(t *testing.T) {
 		w.WriteHeader(200)
 	}))
 	defer svr.Close()
-	client := NewClient()
-	client.apiURL = svr.URL
+	client := NewClient(WithBaseURL(svr.URL))
 
-	resp, err := client.getData()
+	resp, _, err := client.getData(context.Background(), client.summaryURL(), "")
 	require.NotNil(t, resp)
 	require.NoError(t, err)
 }
@@ -36,11 +41,9 @@ func TestClient_GetDataDoesPassesInEtag(t *testing.T) {
 		w.WriteHeader(200)
 	}))
 	defer svr.Close()
-	client := NewClient()
-	client.apiURL = svr.URL
-	client.etag = &expected
+	client := NewClient(WithBaseURL(svr.URL))
 
-	resp, err := client.getData()
+	resp, _, err := client.getData(context.Background(), client.summaryURL(), expected)
 	require.NotNil(t, resp)
 	require.NoError(t, err)
 }
@@ -52,26 +55,88 @@ func TestClient_GetDataShouldPassInUserAgent(t *testing.T) {
 		w.WriteHeader(200)
 	}))
 	defer svr.Close()
-	client := NewClient()
-	client.apiURL = svr.URL
-	client.etag = &expected
+	client := NewClient(WithBaseURL(svr.URL))
 
-	resp, err := client.getData()
+	resp, _, err := client.getData(context.Background(), client.summaryURL(), "")
 	require.NotNil(t, resp)
 	require.NoError(t, err)
 }
 
-func TestClient_PollShouldReturnNilNil(t *testing.T) {
+func TestClient_GetDataShouldRequestSummary(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/summary.json", r.URL.Path)
+		w.WriteHeader(200)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL + "/"))
+
+	resp, _, err := client.getData(context.Background(), client.summaryURL(), "")
+	require.NotNil(t, resp)
+	require.NoError(t, err)
+}
+
+func TestClient_PollShouldReturnLastSummaryWhenNotModified(t *testing.T) {
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == "foo" {
+			w.WriteHeader(304)
+			return
+		}
+		w.Header().Add("Etag", "foo")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testJsonResponse))
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL))
+
+	first, notModified, err := client.Poll()
+	require.NoError(t, err)
+	require.False(t, notModified)
+	require.NotNil(t, first)
+
+	second, notModified, err := client.Poll()
+	require.NoError(t, err)
+	require.True(t, notModified)
+	require.Same(t, first, second)
+	require.Equal(t, 2, requests)
+}
+
+func TestClient_PollShouldNotSendEtagWithoutSummary(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == "foo" {
+			w.WriteHeader(304)
+			return
+		}
+		w.Header().Add("Etag", "foo")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testJsonResponse))
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+	cache := NewMemoryCache()
+	cache.Store("foo", nil)
+	client := NewClient(WithBaseURL(svr.URL), WithCache(cache))
+
+	status, notModified, err := client.Poll()
+	require.NoError(t, err)
+	require.False(t, notModified)
+	require.NotNil(t, status)
+	require.NotEmpty(t, status.Components)
+}
+
+func TestClient_PollShouldReturnErrWhenNotModifiedWithoutEtag(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(304)
 	}))
 	defer svr.Close()
-	client := NewClient()
-	client.apiURL = svr.URL
+	client := NewClient(WithBaseURL(svr.URL))
 
-	status, err := client.Poll()
+	status, notModified, err := client.Poll()
 	require.Nil(t, status)
-	require.NoError(t, err)
+	require.False(t, notModified)
+	require.Error(t, err)
 }
 
 func TestClient_PollShouldReturnNilErr(t *testing.T) {
@@ -79,10 +144,9 @@ func TestClient_PollShouldReturnNilErr(t *testing.T) {
 		w.WriteHeader(418)
 	}))
 	defer svr.Close()
-	client := NewClient()
-	client.apiURL = svr.URL
+	client := NewClient(WithBaseURL(svr.URL))
 
-	status, err := client.Poll()
+	status, _, err := client.Poll()
 	require.Nil(t, status)
 	require.Error(t, err)
 }
@@ -95,11 +159,158 @@ func TestClient_PollShouldReturnStatusNil(t *testing.T) {
 		require.NoError(t, err)
 	}))
 	defer svr.Close()
-	client := NewClient()
-	client.apiURL = svr.URL
+	cache := NewMemoryCache()
+	client := NewClient(WithBaseURL(svr.URL), WithCache(cache))
 
-	status, err := client.Poll()
+	status, notModified, err := client.Poll()
 	require.NotNil(t, status)
+	require.False(t, notModified)
 	require.NoError(t, err)
-	require.Equal(t, "foo", *client.etag)
+	require.Equal(t, PageStatus{Indicator: INDICATOR_MINOR, Description: "Partially Degraded Service"}, status.Status)
+	etag, cached := cache.Load()
+	require.Equal(t, "foo", etag)
+	require.Same(t, status, cached)
+}
+
+func TestClient_PollWithResult(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == "foo" {
+			w.WriteHeader(304)
+			return
+		}
+		w.Header().Add("Etag", "foo")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testJsonResponse))
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL))
+
+	first, err := client.PollWithResult(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, first.StatusCode)
+	require.False(t, first.NotModified)
+	require.NotNil(t, first.Summary)
+	require.Equal(t, 1, first.Attempts)
+	require.Positive(t, first.Latency)
+
+	second, err := client.PollWithResult(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotModified, second.StatusCode)
+	require.True(t, second.NotModified)
+	require.Same(t, first.Summary, second.Summary)
+}
+
+func TestClient_PollWithResultShouldDescribeFailedPoll(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(418)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL))
+
+	result, err := client.PollWithResult(context.Background())
+	require.Error(t, err)
+	require.Nil(t, result.Summary)
+	require.Equal(t, 418, result.StatusCode)
+	require.Equal(t, 1, result.Attempts)
+}
+
+func TestClient_PollContextShouldStopWhenCancelled(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	status, _, err := client.PollContext(ctx)
+	require.Nil(t, status)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClient_SharedCacheAvoidsDownloadingSummaryAgain(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == "foo" {
+			w.WriteHeader(304)
+			return
+		}
+		w.Header().Add("Etag", "foo")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testJsonResponse))
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+	cache := NewMemoryCache()
+	first := NewClient(WithBaseURL(svr.URL), WithCache(cache))
+	second := NewClient(WithBaseURL(svr.URL), WithCache(cache))
+
+	expected, _, err := first.Poll()
+	require.NoError(t, err)
+	status, notModified, err := second.Poll()
+	require.NoError(t, err)
+	require.True(t, notModified)
+	require.Same(t, expected, status)
+}
+
+func TestClient_WithUserAgent(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "release-bot/1.0", r.Header.Get("User-Agent"))
+		w.WriteHeader(200)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithUserAgent("release-bot/1.0"))
+
+	resp, _, err := client.getData(context.Background(), client.summaryURL(), "")
+	require.NotNil(t, resp)
+	require.NoError(t, err)
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}))
+	defer svr.Close()
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(WithBaseURL(svr.URL), WithLogger(logger))
+
+	_, _, err := client.getData(context.Background(), client.summaryURL(), "")
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "requesting status")
+	require.Contains(t, buf.String(), "status=200")
+}
+
+func TestClient_IncidentHistory(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v2/incidents.json", r.URL.Path)
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testIncidentsResponse))
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL))
+
+	incidents, err := client.IncidentHistory(context.Background())
+	require.NoError(t, err)
+	require.Len(t, incidents, 2)
+	require.Equal(t, "Disruption with some GitHub services", incidents[0].Name)
+	require.Equal(t, INCIDENT_RESOLVED, incidents[0].Status)
+	require.Equal(t, "minor", incidents[0].Impact)
+	require.Equal(t, 2024, incidents[0].CreatedAt.Year())
+	require.Equal(t, "update2", incidents[0].IncidentUpdates[0].ID)
+	require.NotNil(t, incidents[0].ResolvedAt.Time)
+	require.Nil(t, incidents[1].ResolvedAt)
+}
+
+func TestClient_IncidentHistoryShouldReturnErr(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL))
+
+	incidents, err := client.IncidentHistory(context.Background())
+	require.Nil(t, incidents)
+	require.Error(t, err)
 }
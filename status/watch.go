@@ -0,0 +1,29 @@
+package status
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPollInterval is how often Watch polls unless WithPollInterval says otherwise
+const DefaultPollInterval = time.Minute
+
+// Event is the outcome of a single poll made while watching
+type Event struct {
+	Time        time.Time     // when the poll completed
+	Status      *SystemStatus // the current summary, nil if Err is set
+	NotModified bool          // true if nothing changed since the previous poll
+	StatusCode  int           // HTTP status code of the response, 0 if none was received
+	Latency     time.Duration // how long the request took to respond
+	Err         error         // the reason the poll failed, if it did
+}
+
+// Watch polls immediately and then at the poll interval until ctx is cancelled, sending the
+// outcome of each poll on the returned channel. The channel is closed once ctx is cancelled.
+// Use a Watcher directly to share the polls between multiple consumers.
+func (c *Client) Watch(ctx context.Context) <-chan Event {
+	watcher := NewWatcher(c, c.interval)
+	events, _ := watcher.Subscribe(1)
+	go watcher.Run(ctx)
+	return events
+}
@@ -0,0 +1,57 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Watch(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == "foo" {
+			w.WriteHeader(304)
+			return
+		}
+		w.Header().Add("Etag", "foo")
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(testJsonResponse))
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithPollInterval(time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := client.Watch(ctx)
+
+	first := <-events
+	require.NoError(t, first.Err)
+	require.NotNil(t, first.Status)
+
+	second := <-events
+	require.NoError(t, second.Err)
+	require.True(t, second.NotModified)
+	require.Same(t, first.Status, second.Status)
+
+	cancel()
+	for range events {
+		// Drain until the channel is closed
+	}
+}
+
+func TestClient_WatchReportsErrors(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer svr.Close()
+	client := NewClient(WithBaseURL(svr.URL), WithPollInterval(time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	event := <-client.Watch(ctx)
+	require.Error(t, event.Err)
+	require.Nil(t, event.Status)
+}
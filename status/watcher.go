@@ -0,0 +1,264 @@
+package status
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Watcher polls a Client on an interval and fans the outcome of every poll out to any number
+// of subscribers, so a renderer, notifier or history writer can share a single poll stream.
+//
+// Subscribers that fall behind never block the Watcher or each other, when a subscriber's
+// buffer is full its oldest event is dropped in favor of the newest one.
+type Watcher struct {
+	client  *Client
+	refresh chan struct{}
+	control chan struct{} // wakes Run when the interval changes or polling is paused or resumed
+
+	mu          sync.Mutex
+	interval    time.Duration
+	paused      bool
+	nextPoll    time.Time
+	subscribers map[chan Event]struct{}
+	latest      *Event
+	stopped     bool
+}
+
+// pollOutcome is the event produced by a single poll, tagged so outdated polls can be ignored
+type pollOutcome struct {
+	id    int
+	event Event
+}
+
+// NewWatcher creates a Watcher that polls client every interval once Run is called
+func NewWatcher(client *Client, interval time.Duration) *Watcher {
+	return &Watcher{
+		client:      client,
+		interval:    interval,
+		refresh:     make(chan struct{}, 1),
+		control:     make(chan struct{}, 1),
+		subscribers: map[chan Event]struct{}{},
+	}
+}
+
+// Subscribe returns a channel receiving every event published by the Watcher, starting with
+// the most recent one if a poll has already completed, along with a function to unsubscribe.
+// The channel buffers up to buffer events (at least 1) and is closed when the Watcher stops
+// or the subscriber unsubscribes.
+func (w *Watcher) Subscribe(buffer int) (<-chan Event, func()) {
+	if buffer < 1 {
+		buffer = 1
+	}
+	events := make(chan Event, buffer)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		close(events)
+		return events, func() {}
+	}
+	if w.latest != nil {
+		events <- *w.latest
+	}
+	w.subscribers[events] = struct{}{}
+
+	unsubscribe := func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if _, ok := w.subscribers[events]; ok {
+			delete(w.subscribers, events)
+			close(events)
+		}
+	}
+	return events, unsubscribe
+}
+
+// Refresh polls immediately, cancelling any poll that is still in flight, and restarts the
+// interval. It does nothing if the Watcher is not running.
+func (w *Watcher) Refresh() {
+	select {
+	case w.refresh <- struct{}{}:
+	default:
+		// A refresh is already pending
+	}
+}
+
+// Pause stops polling on the interval until Resume is called, Refresh still polls while paused
+func (w *Watcher) Pause() {
+	w.mu.Lock()
+	w.paused = true
+	w.mu.Unlock()
+	w.wake()
+}
+
+// Resume restarts polling on the interval, polling immediately if a poll was due while paused
+func (w *Watcher) Resume() {
+	w.mu.Lock()
+	w.paused = false
+	w.mu.Unlock()
+	w.wake()
+}
+
+// Paused reports whether polling on the interval is paused
+func (w *Watcher) Paused() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.paused
+}
+
+// SetInterval changes how often the Watcher polls, the next poll is due interval from now.
+// Intervals that aren't positive are ignored.
+func (w *Watcher) SetInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	w.mu.Lock()
+	w.interval = interval
+	w.mu.Unlock()
+	w.wake()
+}
+
+// Interval returns how often the Watcher polls
+func (w *Watcher) Interval() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.interval
+}
+
+// NextPoll returns when the next poll on the interval is due, ok is false while paused or
+// when the Watcher is not running
+func (w *Watcher) NextPoll() (next time.Time, ok bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.nextPoll, !w.nextPoll.IsZero()
+}
+
+// wake tells Run to pick up a change to the interval or pause state
+func (w *Watcher) wake() {
+	select {
+	case w.control <- struct{}{}:
+	default:
+		// Run hasn't picked up the previous change yet, it will see this one too
+	}
+}
+
+// Run polls immediately and then every interval until ctx is cancelled, at which point any
+// poll in flight is aborted and every subscriber's channel is closed.
+func (w *Watcher) Run(ctx context.Context) {
+	defer w.stop()
+	timer := time.NewTimer(w.Interval())
+	defer timer.Stop()
+
+	// due is when the latest scheduled poll was due, to catch up on polls missed while paused
+	var due time.Time
+	paused := false
+	schedule := func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		paused = w.paused
+		if paused {
+			timer.Stop()
+			w.nextPoll = time.Time{}
+			return
+		}
+		timer.Reset(w.interval)
+		due = time.Now().Add(w.interval)
+		w.nextPoll = due
+	}
+
+	results := make(chan pollOutcome)
+	pollID := 0
+	inFlight := false
+	cancelPoll := context.CancelFunc(func() {})
+	defer func() { cancelPoll() }()
+
+	startPoll := func() {
+		cancelPoll()
+		pollCtx, cancel := context.WithCancel(ctx)
+		cancelPoll = cancel
+		pollID++
+		inFlight = true
+		id := pollID
+		go func() {
+			defer cancel()
+			result, err := w.client.PollWithResult(pollCtx)
+			event := Event{
+				Time:        time.Now(),
+				Status:      result.Summary,
+				NotModified: result.NotModified,
+				StatusCode:  result.StatusCode,
+				Latency:     result.Latency,
+				Err:         err,
+			}
+			select {
+			case results <- pollOutcome{id: id, event: event}:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	startPoll()
+	schedule()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			if !inFlight {
+				startPoll()
+			}
+			schedule()
+		case <-w.refresh:
+			startPoll()
+			schedule()
+		case <-w.control:
+			wasPaused := paused
+			if wasPaused && !w.Paused() && !inFlight && time.Now().After(due) {
+				startPoll()
+			}
+			schedule()
+		case result := <-results:
+			if result.id != pollID {
+				// A newer poll has been started since this one, its result is outdated
+				continue
+			}
+			inFlight = false
+			w.publish(result.event)
+		}
+	}
+}
+
+// publish sends event to every subscriber, dropping a subscriber's oldest event if needed
+func (w *Watcher) publish(event Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.latest = &event
+	for subscriber := range w.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			// The subscriber is falling behind, make room for the newest event
+			select {
+			case <-subscriber:
+			default:
+			}
+			select {
+			case subscriber <- event:
+			default:
+			}
+		}
+	}
+}
+
+// stop closes every subscriber's channel and prevents new subscriptions
+func (w *Watcher) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+	w.nextPoll = time.Time{}
+	for subscriber := range w.subscribers {
+		close(subscriber)
+	}
+	w.subscribers = map[chan Event]struct{}{}
+}
@@ -0,0 +1,204 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStatusServer(requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(testJsonResponse))
+	}))
+}
+
+func TestWatcher_FansOutToSubscribers(t *testing.T) {
+	requests := new(atomic.Int32)
+	svr := newTestStatusServer(requests)
+	defer svr.Close()
+	watcher := NewWatcher(NewClient(WithBaseURL(svr.URL)), time.Hour)
+	first, _ := watcher.Subscribe(1)
+	second, _ := watcher.Subscribe(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go watcher.Run(ctx)
+
+	firstEvent := <-first
+	secondEvent := <-second
+	require.NoError(t, firstEvent.Err)
+	require.Equal(t, http.StatusOK, firstEvent.StatusCode)
+	require.Equal(t, firstEvent, secondEvent)
+	require.Equal(t, int32(1), requests.Load())
+
+	cancel()
+	_, ok := <-first
+	require.False(t, ok)
+	_, ok = <-second
+	require.False(t, ok)
+}
+
+func TestWatcher_Refresh(t *testing.T) {
+	requests := new(atomic.Int32)
+	svr := newTestStatusServer(requests)
+	defer svr.Close()
+	watcher := NewWatcher(NewClient(WithBaseURL(svr.URL)), time.Hour)
+	events, _ := watcher.Subscribe(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	<-events
+	watcher.Refresh()
+	event := <-events
+	require.NoError(t, event.Err)
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestWatcher_RefreshCancelsPollInFlight(t *testing.T) {
+	requests := new(atomic.Int32)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Hang the first request until it is cancelled
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(testJsonResponse))
+	}))
+	defer svr.Close()
+	watcher := NewWatcher(NewClient(WithBaseURL(svr.URL)), time.Hour)
+	events, _ := watcher.Subscribe(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	require.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, time.Millisecond)
+	watcher.Refresh()
+	event := <-events
+	require.NoError(t, event.Err)
+	require.NotNil(t, event.Status)
+}
+
+func TestWatcher_SlowSubscriberReceivesNewestEvent(t *testing.T) {
+	watcher := NewWatcher(NewClient(), time.Hour)
+	events, _ := watcher.Subscribe(1)
+
+	watcher.publish(Event{Time: time.Unix(1, 0)})
+	watcher.publish(Event{Time: time.Unix(2, 0)})
+	event := <-events
+	require.Equal(t, time.Unix(2, 0), event.Time)
+}
+
+func TestWatcher_SubscribeReceivesLatestEvent(t *testing.T) {
+	watcher := NewWatcher(NewClient(), time.Hour)
+	watcher.publish(Event{Time: time.Unix(1, 0)})
+
+	events, _ := watcher.Subscribe(1)
+	event := <-events
+	require.Equal(t, time.Unix(1, 0), event.Time)
+}
+
+func TestWatcher_Unsubscribe(t *testing.T) {
+	watcher := NewWatcher(NewClient(), time.Hour)
+	events, unsubscribe := watcher.Subscribe(1)
+	unsubscribe()
+	unsubscribe()
+
+	watcher.publish(Event{})
+	_, ok := <-events
+	require.False(t, ok)
+}
+
+func TestWatcher_PauseAndResume(t *testing.T) {
+	requests := new(atomic.Int32)
+	svr := newTestStatusServer(requests)
+	defer svr.Close()
+	watcher := NewWatcher(NewClient(WithBaseURL(svr.URL)), 20*time.Millisecond)
+	events, _ := watcher.Subscribe(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	<-events
+	watcher.Pause()
+	require.Eventually(t, func() bool {
+		_, ok := watcher.NextPoll()
+		return !ok
+	}, time.Second, time.Millisecond)
+	require.True(t, watcher.Paused())
+	// Drain any poll that completed before the pause took effect
+	select {
+	case <-events:
+	case <-time.After(50 * time.Millisecond):
+	}
+	paused := requests.Load()
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, paused, requests.Load(), "expected no polls while paused")
+
+	// A poll was due while paused, so resuming polls immediately
+	watcher.Resume()
+	event := <-events
+	require.NoError(t, event.Err)
+	require.False(t, watcher.Paused())
+	_, ok := watcher.NextPoll()
+	require.True(t, ok)
+}
+
+func TestWatcher_RefreshWhilePaused(t *testing.T) {
+	requests := new(atomic.Int32)
+	svr := newTestStatusServer(requests)
+	defer svr.Close()
+	watcher := NewWatcher(NewClient(WithBaseURL(svr.URL)), time.Hour)
+	watcher.Pause()
+	events, _ := watcher.Subscribe(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	<-events
+	watcher.Refresh()
+	<-events
+	require.Equal(t, int32(2), requests.Load())
+	_, ok := watcher.NextPoll()
+	require.False(t, ok)
+}
+
+func TestWatcher_SetInterval(t *testing.T) {
+	requests := new(atomic.Int32)
+	svr := newTestStatusServer(requests)
+	defer svr.Close()
+	watcher := NewWatcher(NewClient(WithBaseURL(svr.URL)), time.Hour)
+	events, _ := watcher.Subscribe(1)
+
+	_, ok := watcher.NextPoll()
+	require.False(t, ok, "expected no next poll before the watcher runs")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+	<-events
+
+	watcher.SetInterval(0)
+	require.Equal(t, time.Hour, watcher.Interval())
+
+	watcher.SetInterval(10 * time.Millisecond)
+	require.Equal(t, 10*time.Millisecond, watcher.Interval())
+	require.Eventually(t, func() bool {
+		next, ok := watcher.NextPoll()
+		return ok && time.Until(next) < time.Minute
+	}, time.Second, time.Millisecond)
+	event := <-events
+	require.NoError(t, event.Err)
+	require.GreaterOrEqual(t, requests.Load(), int32(2))
+}